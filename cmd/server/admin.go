@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/icl00ud/goban/internal/config"
+	"github.com/icl00ud/goban/internal/repository"
+	"github.com/icl00ud/goban/internal/services"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const commandUsage = `Usage: goban [command]
+
+Without a command goban runs the server.
+
+Commands:
+  create-admin -email EMAIL [-name NAME]
+      Make the user with EMAIL an administrator, creating the account if it
+      doesn't exist. The password of a new account is read from
+      GOBAN_ADMIN_PASSWORD or standard input.
+`
+
+// runCommand runs an administration command and returns the exit code
+func runCommand(db *gorm.DB, cfg *config.Config, args []string) int {
+	// Keep the command's output readable
+	db = db.Session(&gorm.Session{Logger: db.Logger.LogMode(logger.Warn)})
+
+	switch args[0] {
+	case "create-admin":
+		if err := createAdmin(db, cfg, args[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "create-admin: %v\n", err)
+			return 1
+		}
+		return 0
+	case "help", "-h", "-help", "--help":
+		fmt.Print(commandUsage)
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", args[0], commandUsage)
+		return 2
+	}
+}
+
+// createAdmin promotes or creates an administrator, so an instance with
+// closed or invite-only registration can be set up
+func createAdmin(db *gorm.DB, cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("create-admin", flag.ContinueOnError)
+	email := flags.String("email", "", "email address of the administrator")
+	name := flags.String("name", "", "name of a new administrator (defaults to the email's local part)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	*email = strings.TrimSpace(*email)
+	if !strings.Contains(*email, "@") {
+		return errors.New("a valid -email is required")
+	}
+
+	// Creating administrators only needs the user repository
+	adminService := services.NewAdminService(repository.NewUserRepository(db), nil, nil, nil, nil, cfg.AppURL)
+
+	user, err := adminService.PromoteByEmail(*email)
+	if err == nil {
+		fmt.Printf("%s is now an administrator\n", user.Email)
+		return nil
+	}
+	if !errors.Is(err, services.ErrUserNotFound) {
+		return err
+	}
+
+	password, err := readPassword()
+	if err != nil {
+		return err
+	}
+	if len(password) < 6 {
+		return errors.New("password must be at least 6 characters")
+	}
+
+	if strings.TrimSpace(*name) == "" {
+		*name = (*email)[:strings.Index(*email, "@")]
+	}
+
+	user, err = adminService.CreateAdmin(*email, strings.TrimSpace(*name), password)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Created administrator %s\n", user.Email)
+	return nil
+}
+
+// readPassword reads the new administrator's password from the environment
+// or the first line of standard input
+func readPassword() (string, error) {
+	if password := os.Getenv("GOBAN_ADMIN_PASSWORD"); password != "" {
+		return password, nil
+	}
+
+	fmt.Fprint(os.Stderr, "Password: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -1,11 +1,13 @@
 package main
 
 import (
+	"bytes"
 	"io/fs"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"regexp"
 	"strings"
 	"syscall"
 	"time"
@@ -20,7 +22,11 @@ import (
 	goban "github.com/icl00ud/goban"
 	"github.com/icl00ud/goban/internal/config"
 	"github.com/icl00ud/goban/internal/database"
+	"github.com/icl00ud/goban/internal/mail"
+	"github.com/icl00ud/goban/internal/middleware"
 	"github.com/icl00ud/goban/internal/router"
+	"github.com/icl00ud/goban/internal/storage"
+	"github.com/valyala/fasthttp"
 )
 
 func main() {
@@ -38,6 +44,23 @@ func main() {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
+	// Run an administration command instead of the server when one is given
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(db, cfg, os.Args[1:]))
+	}
+
+	// Set up attachment storage
+	store, err := storage.New(cfg)
+	if err != nil {
+		log.Fatalf("Failed to set up attachment storage: %v", err)
+	}
+
+	// Set up outbound email
+	mailer, err := mail.New(cfg)
+	if err != nil {
+		log.Fatalf("Failed to set up mail: %v", err)
+	}
+
 	// Create Fiber app
 	app := fiber.New(fiber.Config{
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
@@ -52,17 +75,31 @@ func main() {
 		},
 	})
 
+	// Requests keep the default body limit, except attachment uploads, which
+	// leave room for the multipart envelope around the largest allowed file
+	uploadBodyLimit := int(cfg.MaxUploadSize) + 1024*1024
+	app.Server().HeaderReceived = func(header *fasthttp.RequestHeader) fasthttp.RequestConfig {
+		if isUpload(header) {
+			return fasthttp.RequestConfig{MaxRequestBodySize: uploadBodyLimit}
+		}
+		return fasthttp.RequestConfig{}
+	}
+
 	// Middleware
 	app.Use(recover.New())
+	app.Use(middleware.ClientIP(cfg.TrustedProxies))
 	app.Use(logger.New(logger.Config{
 		Next: func(c *fiber.Ctx) bool {
 			return c.Path() == "/api/v1/health"
 		},
 	}))
 	app.Use(compress.New(compress.Config{
+		Next:  isStreamed,
 		Level: compress.LevelBestSpeed, // Optimize for speed in production
 	}))
-	app.Use(etag.New())
+	app.Use(etag.New(etag.Config{
+		Next: isStreamed,
+	}))
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:     "http://localhost:5173,http://localhost:8080",
 		AllowCredentials: true,
@@ -71,7 +108,7 @@ func main() {
 	}))
 
 	// Setup API routes
-	router.Setup(app, db, cfg)
+	router.Setup(app, db, cfg, store, mailer)
 
 	// Setup static file serving with SPA fallback
 	setupStaticServing(app)
@@ -97,6 +134,26 @@ func main() {
 	log.Println("Server exited")
 }
 
+// isStreamed reports whether the request is for a streaming endpoint, which
+// must not be buffered by the compress and etag middleware
+func isStreamed(c *fiber.Ctx) bool {
+	path := c.Path()
+	if !strings.HasPrefix(path, "/api/") {
+		return false
+	}
+	return strings.HasSuffix(path, "/events") || strings.HasSuffix(path, "/export.csv") || strings.HasSuffix(path, "/export.md")
+}
+
+// uploadPath matches the attachment upload route
+var uploadPath = regexp.MustCompile(`^/api/v1/cards/\d+/attachments/?$`)
+
+// isUpload reports whether a request is an attachment upload, before its
+// body has been read
+func isUpload(header *fasthttp.RequestHeader) bool {
+	path, _, _ := bytes.Cut(header.RequestURI(), []byte("?"))
+	return header.IsPost() && uploadPath.Match(path)
+}
+
 // setupStaticServing configures static file serving from embedded files with SPA fallback
 func setupStaticServing(app *fiber.App) {
 	// Get the embedded filesystem, stripping the "web/dist" prefix
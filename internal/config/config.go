@@ -2,6 +2,10 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -11,18 +15,129 @@ type Config struct {
 	DBDriver    string
 	DatabaseURL string
 	JWTSecret   string
+	// AppURL is the public address of the app, used in emailed links
+	AppURL string
+	// Reverse proxies whose X-Forwarded-For header is trusted for client IPs
+	TrustedProxies []string
+
+	// Attachments
+	StorageDriver      string
+	UploadDir          string
+	MaxUploadSize      int64
+	AllowedUploadTypes []string
+	S3Endpoint         string
+	S3Region           string
+	S3Bucket           string
+	S3AccessKey        string
+	S3SecretKey        string
+	S3UseSSL           bool
+
+	// Trash
+	TrashRetentionDays int
+
+	// OpenID Connect single sign-on, enabled when an issuer and client ID are set
+	OIDCIssuer         string
+	OIDCClientID       string
+	OIDCClientSecret   string
+	OIDCRedirectURL    string
+	OIDCAllowedDomains []string
+
+	// Outbound email, logged instead of sent when no SMTP host is set
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+	SMTPSecurity string
+	// Logs the content of unsent emails, including their links, for local
+	// development without an SMTP server
+	MailLogContent bool
+
+	// Who may create an account: open, invite or closed
+	RegistrationMode           string
+	RegistrationAllowedDomains []string
+
+	// Rate limits; a limit of 0 disables it
+	AuthRateLimit        int
+	AuthRateWindow       time.Duration
+	AccountRateLimit     int
+	AccountRateWindow    time.Duration
+	APIRateLimit         int
+	APIRateWindow        time.Duration
+	LoginMaxFailures     int
+	LoginLockoutDuration time.Duration
 }
 
 func Load() *Config {
 	// Load .env file if it exists (ignore error if not found)
 	_ = godotenv.Load()
 
+	dbDriver := getEnv("DB_DRIVER", "sqlite")
+	databaseURL := getEnv("DATABASE_URL", "./goban.db")
+
 	return &Config{
 		Port:        getEnv("PORT", "8080"),
-		DBDriver:    getEnv("DB_DRIVER", "sqlite"),
-		DatabaseURL: getEnv("DATABASE_URL", "./goban.db"),
+		DBDriver:    dbDriver,
+		DatabaseURL: databaseURL,
 		JWTSecret:   getEnv("JWT_SECRET", "default-secret-change-me"),
+		AppURL:      strings.TrimSuffix(getEnv("APP_URL", "http://localhost:8080"), "/"),
+
+		TrustedProxies: getEnvList("TRUSTED_PROXIES", ""),
+
+		StorageDriver:      getEnv("STORAGE_DRIVER", "local"),
+		UploadDir:          getEnv("UPLOAD_DIR", defaultUploadDir(dbDriver, databaseURL)),
+		MaxUploadSize:      getEnvInt64("MAX_UPLOAD_SIZE_MB", 10) * 1024 * 1024,
+		AllowedUploadTypes: getEnvList("ALLOWED_UPLOAD_TYPES", "image/*,text/plain,text/csv,application/pdf,application/json,application/zip"),
+		S3Endpoint:         getEnv("S3_ENDPOINT", "s3.amazonaws.com"),
+		S3Region:           getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:           getEnv("S3_BUCKET", ""),
+		S3AccessKey:        getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:        getEnv("S3_SECRET_KEY", ""),
+		S3UseSSL:           getEnvBool("S3_USE_SSL", true),
+
+		TrashRetentionDays: int(getEnvInt64("TRASH_RETENTION_DAYS", 30)),
+
+		OIDCIssuer:         getEnv("OIDC_ISSUER", ""),
+		OIDCClientID:       getEnv("OIDC_CLIENT_ID", ""),
+		OIDCClientSecret:   getEnv("OIDC_CLIENT_SECRET", ""),
+		OIDCRedirectURL:    getEnv("OIDC_REDIRECT_URL", ""),
+		OIDCAllowedDomains: getEnvList("OIDC_ALLOWED_DOMAINS", ""),
+
+		SMTPHost:     getEnv("SMTP_HOST", ""),
+		SMTPPort:     int(getEnvInt64("SMTP_PORT", 587)),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:     getEnv("SMTP_FROM", "Goban <noreply@localhost>"),
+		SMTPSecurity: getEnv("SMTP_SECURITY", "starttls"),
+
+		MailLogContent: getEnvBool("MAIL_LOG_CONTENT", false),
+
+		RegistrationMode:           strings.ToLower(getEnv("REGISTRATION_MODE", "open")),
+		RegistrationAllowedDomains: getEnvList("REGISTRATION_ALLOWED_DOMAINS", ""),
+
+		AuthRateLimit:        int(getEnvInt64("AUTH_RATE_LIMIT", 20)),
+		AuthRateWindow:       getEnvDuration("AUTH_RATE_WINDOW", time.Minute),
+		AccountRateLimit:     int(getEnvInt64("ACCOUNT_RATE_LIMIT", 10)),
+		AccountRateWindow:    getEnvDuration("ACCOUNT_RATE_WINDOW", 15*time.Minute),
+		APIRateLimit:         int(getEnvInt64("API_RATE_LIMIT", 600)),
+		APIRateWindow:        getEnvDuration("API_RATE_WINDOW", time.Minute),
+		LoginMaxFailures:     int(getEnvInt64("LOGIN_MAX_FAILURES", 5)),
+		LoginLockoutDuration: getEnvDuration("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+	}
+}
+
+// OIDCEnabled reports whether single sign-on is configured
+func (c *Config) OIDCEnabled() bool {
+	return c.OIDCIssuer != "" && c.OIDCClientID != ""
+}
+
+// defaultUploadDir keeps local attachments next to the SQLite database file
+func defaultUploadDir(dbDriver, databaseURL string) string {
+	if dbDriver != "sqlite" {
+		return "./attachments"
 	}
+	dbPath := strings.SplitN(databaseURL, "?", 2)[0]
+	return filepath.Join(filepath.Dir(dbPath), "attachments")
 }
 
 func getEnv(key, defaultValue string) string {
@@ -31,3 +146,34 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvInt64(key string, defaultValue int64) int64 {
+	if value, err := strconv.ParseInt(os.Getenv(key), 10, 64); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value, err := strconv.ParseBool(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvList(key, defaultValue string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, defaultValue), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
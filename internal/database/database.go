@@ -51,11 +51,32 @@ func Migrate(db *gorm.DB) error {
 		&models.Board{},
 		&models.Column{},
 		&models.Card{},
+		&models.BoardMember{},
+		&models.Label{},
+		&models.Comment{},
+		&models.CommentRevision{},
+		&models.Checklist{},
+		&models.ChecklistItem{},
+		&models.Attachment{},
+		&models.Activity{},
+		&models.Swimlane{},
+		&models.BoardTemplate{},
+		&models.APIToken{},
+		&models.Session{},
+		&models.OIDCIdentity{},
+		&models.RecoveryCode{},
+		&models.LoginChallenge{},
+		&models.UserToken{},
+		&models.Invite{},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
+	if err := migrateSearch(db); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
 	log.Println("Database migrations completed successfully")
 	return nil
 }
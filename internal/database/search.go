@@ -0,0 +1,115 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// searchSource describes a table whose text columns are full-text indexed
+type searchSource struct {
+	table   string
+	columns []string
+}
+
+// searchSources are indexed in this order; the first column of each table
+// carries the most weight in ranking
+var searchSources = []searchSource{
+	{table: "boards", columns: []string{"name"}},
+	{table: "cards", columns: []string{"title", "description"}},
+	{table: "comments", columns: []string{"body"}},
+}
+
+// migrateSearch creates the full-text search indexes for the database driver
+func migrateSearch(db *gorm.DB) error {
+	switch db.Dialector.Name() {
+	case "sqlite":
+		return migrateSQLiteSearch(db)
+	case "postgres":
+		return migratePostgresSearch(db)
+	default:
+		return nil
+	}
+}
+
+// migrateSQLiteSearch creates one FTS5 table per source, keyed by the source
+// row ID and kept in sync by triggers. SQLite builds without FTS5 fall back to
+// substring search.
+func migrateSQLiteSearch(db *gorm.DB) error {
+	var compiled int
+	if err := db.Raw("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&compiled).Error; err != nil {
+		return err
+	}
+	if compiled == 0 {
+		log.Println("Warning: SQLite was built without FTS5 (build with -tags sqlite_fts5); search falls back to substring matching")
+		return nil
+	}
+
+	for _, source := range searchSources {
+		ftsTable := source.table + "_fts"
+		columns := strings.Join(source.columns, ", ")
+		newValues := "new." + strings.Join(source.columns, ", new.")
+
+		var exists int64
+		if err := db.Raw("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", ftsTable).Scan(&exists).Error; err != nil {
+			return err
+		}
+
+		assignments := make([]string, len(source.columns))
+		for i, column := range source.columns {
+			assignments[i] = fmt.Sprintf("%s = new.%s", column, column)
+		}
+
+		statements := []string{
+			fmt.Sprintf("CREATE VIRTUAL TABLE IF NOT EXISTS %s USING fts5(%s, tokenize = 'unicode61 remove_diacritics 2')", ftsTable, columns),
+			fmt.Sprintf("CREATE TRIGGER IF NOT EXISTS %[1]s_insert AFTER INSERT ON %[2]s BEGIN INSERT INTO %[1]s(rowid, %[3]s) VALUES (new.id, %[4]s); END",
+				ftsTable, source.table, columns, newValues),
+			fmt.Sprintf("CREATE TRIGGER IF NOT EXISTS %[1]s_update AFTER UPDATE OF %[3]s ON %[2]s BEGIN UPDATE %[1]s SET %[4]s WHERE rowid = new.id; END",
+				ftsTable, source.table, columns, strings.Join(assignments, ", ")),
+			fmt.Sprintf("CREATE TRIGGER IF NOT EXISTS %[1]s_delete AFTER DELETE ON %[2]s BEGIN DELETE FROM %[1]s WHERE rowid = old.id; END",
+				ftsTable, source.table),
+		}
+		if exists == 0 {
+			// Index rows written before the search table existed
+			statements = append(statements, fmt.Sprintf("INSERT INTO %[1]s(rowid, %[2]s) SELECT id, %[2]s FROM %[3]s",
+				ftsTable, columns, source.table))
+		}
+
+		for _, statement := range statements {
+			if err := db.Exec(statement).Error; err != nil {
+				return fmt.Errorf("failed to set up search index for %s: %w", source.table, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// migratePostgresSearch adds a generated, weighted tsvector column with a GIN
+// index to every source table
+func migratePostgresSearch(db *gorm.DB) error {
+	weights := []string{"A", "B", "C", "D"}
+
+	for _, source := range searchSources {
+		vectors := make([]string, len(source.columns))
+		for i, column := range source.columns {
+			vectors[i] = fmt.Sprintf("setweight(to_tsvector('simple', coalesce(%s, '')), '%s')", column, weights[i])
+		}
+
+		statements := []string{
+			fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (%s) STORED",
+				source.table, strings.Join(vectors, " || ")),
+			fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%[1]s_search_vector ON %[1]s USING GIN (search_vector)", source.table),
+		}
+
+		for _, statement := range statements {
+			if err := db.Exec(statement).Error; err != nil {
+				return fmt.Errorf("failed to set up search index for %s: %w", source.table, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ActivityResponse represents an audit trail entry in responses. Before and
+// After are JSON snapshots of the entity, or null when not applicable.
+type ActivityResponse struct {
+	ID         uint            `json:"id"`
+	BoardID    uint            `json:"board_id"`
+	Actor      UserResponse    `json:"actor"`
+	EntityType string          `json:"entity_type"`
+	EntityID   uint            `json:"entity_id"`
+	Action     string          `json:"action"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// ActivityPageResponse represents a page of activity. NextCursor is passed as
+// the cursor query parameter to fetch the next page and is null on the last page.
+type ActivityPageResponse struct {
+	Items      []ActivityResponse `json:"items"`
+	NextCursor *uint              `json:"next_cursor"`
+}
@@ -0,0 +1,53 @@
+package dto
+
+import "time"
+
+// AdminUserResponse represents a user in the administration user list
+type AdminUserResponse struct {
+	ID               uint       `json:"id"`
+	Email            string     `json:"email"`
+	Name             string     `json:"name"`
+	IsAdmin          bool       `json:"is_admin"`
+	DisabledAt       *time.Time `json:"disabled_at"`
+	EmailVerified    bool       `json:"email_verified"`
+	TwoFactorEnabled bool       `json:"two_factor_enabled"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// AdminUpdateUserRequest represents an administrator's changes to a user;
+// omitted fields are left unchanged
+type AdminUpdateUserRequest struct {
+	IsAdmin  *bool `json:"is_admin"`
+	Disabled *bool `json:"disabled"`
+}
+
+// AdminResetPasswordRequest sets a user's password, or emails them a reset
+// link when the password is empty
+type AdminResetPasswordRequest struct {
+	Password string `json:"password"`
+}
+
+// CreateInviteRequest represents the create invite request body
+type CreateInviteRequest struct {
+	Email     string     `json:"email"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// InviteResponse represents an invite in responses, without its code
+type InviteResponse struct {
+	ID        uint       `json:"id"`
+	Email     string     `json:"email"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	UsedBy    *string    `json:"used_by"`
+	CreatedBy string     `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// CreatedInviteResponse is returned once when an invite is created and is the
+// only time its code is shown
+type CreatedInviteResponse struct {
+	InviteResponse
+	Code string `json:"code"`
+	Link string `json:"link"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+// CreateAPITokenRequest represents the create API token request body
+type CreateAPITokenRequest struct {
+	Name      string     `json:"name"`
+	Scope     string     `json:"scope"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// APITokenResponse represents an API token in responses, without its secret
+type APITokenResponse struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scope      string     `json:"scope"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedAPITokenResponse is returned once when a token is created and is the
+// only time its secret is shown
+type CreatedAPITokenResponse struct {
+	APITokenResponse
+	Token string `json:"token"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+// AttachmentResponse represents attachment metadata in responses
+type AttachmentResponse struct {
+	ID          uint         `json:"id"`
+	CardID      uint         `json:"card_id"`
+	FileName    string       `json:"file_name"`
+	ContentType string       `json:"content_type"`
+	Size        int64        `json:"size"`
+	Uploader    UserResponse `json:"uploader"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
@@ -2,9 +2,10 @@ package dto
 
 // RegisterRequest represents the registration request body
 type RegisterRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
-	Name     string `json:"name"`
+	Email      string `json:"email"`
+	Password   string `json:"password"`
+	Name       string `json:"name"`
+	InviteCode string `json:"invite_code"`
 }
 
 // LoginRequest represents the login request body
@@ -13,9 +14,33 @@ type LoginRequest struct {
 	Password string `json:"password"`
 }
 
+// ForgotPasswordRequest represents a request for a password reset email
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// ResetPasswordRequest represents a password reset with an emailed token
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// VerifyEmailRequest represents an email verification with an emailed token
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
 // UserResponse represents user data in responses
 type UserResponse struct {
-	ID    uint   `json:"id"`
-	Email string `json:"email"`
-	Name  string `json:"name"`
+	ID            uint   `json:"id"`
+	Email         string `json:"email"`
+	Name          string `json:"name"`
+	EmailVerified bool   `json:"email_verified"`
+	IsAdmin       bool   `json:"is_admin"`
+}
+
+// RegistrationPolicyResponse tells the registration form who may sign up
+type RegistrationPolicyResponse struct {
+	Mode           string   `json:"mode"`
+	AllowedDomains []string `json:"allowed_domains"`
 }
@@ -1,31 +1,45 @@
 package dto
 
-// CreateBoardRequest represents the request to create a board
+// CreateBoardRequest represents the request to create a board, optionally
+// from a template
 type CreateBoardRequest struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	Color       string `json:"color"`
+	TemplateID  uint   `json:"template_id"`
 }
 
 // UpdateBoardRequest represents the request to update a board
 type UpdateBoardRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Color       string `json:"color"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Color        string `json:"color"`
+	WIPLimitMode string `json:"wip_limit_mode"`
 }
 
 // BoardResponse represents board data in responses
 type BoardResponse struct {
-	ID          uint             `json:"id"`
-	Name        string           `json:"name"`
-	Description string           `json:"description"`
-	Color       string           `json:"color"`
-	Position    int              `json:"position"`
-	Columns     []ColumnResponse `json:"columns,omitempty"`
-	CreatedAt   string           `json:"created_at"`
+	ID           uint               `json:"id"`
+	Name         string             `json:"name"`
+	Description  string             `json:"description"`
+	Color        string             `json:"color"`
+	Position     int                `json:"position"`
+	WIPLimitMode string             `json:"wip_limit_mode"`
+	Columns      []ColumnResponse   `json:"columns,omitempty"`
+	Swimlanes    []SwimlaneResponse `json:"swimlanes,omitempty"`
+	CreatedAt    string             `json:"created_at"`
 }
 
 // ReorderBoardsRequest represents the request to reorder boards
 type ReorderBoardsRequest struct {
 	BoardIDs []uint `json:"board_ids"`
 }
+
+// DuplicateBoardRequest represents the request to duplicate a board. Columns,
+// WIP limits and swimlanes are always copied.
+type DuplicateBoardRequest struct {
+	Name              string `json:"name"`
+	IncludeCards      bool   `json:"include_cards"`
+	IncludeLabels     bool   `json:"include_labels"`
+	IncludeChecklists bool   `json:"include_checklists"`
+}
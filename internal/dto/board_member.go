@@ -0,0 +1,20 @@
+package dto
+
+// AddMemberRequest represents the request to invite a user to a board
+type AddMemberRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+// UpdateMemberRequest represents the request to change a member's role
+type UpdateMemberRequest struct {
+	Role string `json:"role"`
+}
+
+// BoardMemberResponse represents board member data in responses
+type BoardMemberResponse struct {
+	UserID uint   `json:"user_id"`
+	Email  string `json:"email"`
+	Name   string `json:"name"`
+	Role   string `json:"role"`
+}
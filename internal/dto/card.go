@@ -1,23 +1,34 @@
 package dto
 
+import "time"
+
 // CreateCardRequest represents the request to create a card
 type CreateCardRequest struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Priority    string `json:"priority"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Priority    string     `json:"priority"`
+	StartAt     *time.Time `json:"start_at"`
+	DueAt       *time.Time `json:"due_at"`
+	SwimlaneID  *uint      `json:"swimlane_id"`
 }
 
-// UpdateCardRequest represents the request to update a card
+// UpdateCardRequest represents the request to update a card. Dates are left
+// untouched when omitted and cleared when sent as null.
 type UpdateCardRequest struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Priority    string `json:"priority"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Priority    string              `json:"priority"`
+	StartAt     Optional[time.Time] `json:"start_at"`
+	DueAt       Optional[time.Time] `json:"due_at"`
 }
 
 // MoveCardRequest represents the request to move a card to a different column
+// or swimlane. The swimlane is left untouched when omitted and cleared when
+// sent as null.
 type MoveCardRequest struct {
-	TargetColumnID uint `json:"target_column_id"`
-	Position       int  `json:"position"`
+	TargetColumnID uint           `json:"target_column_id"`
+	SwimlaneID     Optional[uint] `json:"swimlane_id"`
+	Position       int            `json:"position"`
 }
 
 // ReorderCardsRequest represents the request to reorder cards within a column
@@ -26,12 +37,25 @@ type ReorderCardsRequest struct {
 	CardIDs  []uint `json:"card_ids"`
 }
 
+// AssignCardRequest represents the request to assign a user to a card
+type AssignCardRequest struct {
+	UserID uint `json:"user_id"`
+}
+
 // CardResponse represents card data in responses
 type CardResponse struct {
-	ID          uint   `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Position    int    `json:"position"`
-	Priority    string `json:"priority"`
-	ColumnID    uint   `json:"column_id"`
+	ID                uint              `json:"id"`
+	Title             string            `json:"title"`
+	Description       string            `json:"description"`
+	Position          int               `json:"position"`
+	Priority          string            `json:"priority"`
+	ColumnID          uint              `json:"column_id"`
+	SwimlaneID        *uint             `json:"swimlane_id"`
+	StartAt           *time.Time        `json:"start_at"`
+	DueAt             *time.Time        `json:"due_at"`
+	CommentCount      int               `json:"comment_count"`
+	ChecklistProgress ChecklistProgress `json:"checklist_progress"`
+	Assignees         []UserResponse    `json:"assignees"`
+	Labels            []LabelResponse   `json:"labels"`
+	WIPLimitExceeded  bool              `json:"wip_limit_exceeded,omitempty"`
 }
@@ -0,0 +1,55 @@
+package dto
+
+// CreateChecklistRequest represents the request to create a checklist
+type CreateChecklistRequest struct {
+	Title string `json:"title"`
+}
+
+// UpdateChecklistRequest represents the request to rename a checklist
+type UpdateChecklistRequest struct {
+	Title string `json:"title"`
+}
+
+// ReorderChecklistsRequest represents the request to reorder the checklists of a card
+type ReorderChecklistsRequest struct {
+	ChecklistIDs []uint `json:"checklist_ids"`
+}
+
+// CreateChecklistItemRequest represents the request to add a checklist item
+type CreateChecklistItemRequest struct {
+	Text string `json:"text"`
+}
+
+// UpdateChecklistItemRequest represents the request to edit or check/uncheck an item
+type UpdateChecklistItemRequest struct {
+	Text string `json:"text"`
+	Done *bool  `json:"done"`
+}
+
+// ReorderChecklistItemsRequest represents the request to reorder checklist items
+type ReorderChecklistItemsRequest struct {
+	ItemIDs []uint `json:"item_ids"`
+}
+
+// ChecklistItemResponse represents checklist item data in responses
+type ChecklistItemResponse struct {
+	ID       uint   `json:"id"`
+	Text     string `json:"text"`
+	Done     bool   `json:"done"`
+	Position int    `json:"position"`
+}
+
+// ChecklistResponse represents checklist data in responses
+type ChecklistResponse struct {
+	ID       uint                    `json:"id"`
+	Title    string                  `json:"title"`
+	Position int                     `json:"position"`
+	CardID   uint                    `json:"card_id"`
+	Items    []ChecklistItemResponse `json:"items"`
+}
+
+// ChecklistProgress represents how many checklist items of a card are done
+type ChecklistProgress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
@@ -5,9 +5,11 @@ type CreateColumnRequest struct {
 	Title string `json:"title"`
 }
 
-// UpdateColumnRequest represents the request to update a column
+// UpdateColumnRequest represents the request to update a column. The WIP
+// limit is left untouched when omitted and removed when sent as null.
 type UpdateColumnRequest struct {
-	Title string `json:"title"`
+	Title    string        `json:"title"`
+	WIPLimit Optional[int] `json:"wip_limit"`
 }
 
 // ReorderColumnsRequest represents the request to reorder columns
@@ -22,5 +24,6 @@ type ColumnResponse struct {
 	Title    string         `json:"title"`
 	Position int            `json:"position"`
 	BoardID  uint           `json:"board_id"`
+	WIPLimit *int           `json:"wip_limit"`
 	Cards    []CardResponse `json:"cards,omitempty"`
 }
@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+// CommentRequest represents the request to create or edit a comment
+type CommentRequest struct {
+	Body string `json:"body"`
+}
+
+// CommentResponse represents comment data in responses. Body is Markdown.
+type CommentResponse struct {
+	ID        uint         `json:"id"`
+	CardID    uint         `json:"card_id"`
+	Body      string       `json:"body"`
+	Author    UserResponse `json:"author"`
+	EditedAt  *time.Time   `json:"edited_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// CommentRevisionResponse represents a previous version of a comment
+type CommentRevisionResponse struct {
+	Action    string       `json:"action"`
+	Body      string       `json:"body"`
+	User      UserResponse `json:"user"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// CommentHistoryResponse represents a comment with its edit and delete history
+type CommentHistoryResponse struct {
+	Comment   CommentResponse           `json:"comment"`
+	Deleted   bool                      `json:"deleted"`
+	Revisions []CommentRevisionResponse `json:"revisions"`
+}
@@ -0,0 +1,84 @@
+package dto
+
+import "time"
+
+// BoardExportVersion is the schema version of board export documents. Imports
+// only accept documents of this version.
+const BoardExportVersion = 1
+
+// BoardExport is a versioned, self-contained JSON document of a board used
+// for backups and moving boards between instances
+type BoardExport struct {
+	Version    int             `json:"version"`
+	ExportedAt time.Time       `json:"exported_at"`
+	Board      ExportBoardData `json:"board"`
+}
+
+// ExportBoardData holds a board and everything on it. Label and swimlane IDs
+// are only meaningful within the document.
+type ExportBoardData struct {
+	Name         string           `json:"name"`
+	Description  string           `json:"description"`
+	Color        string           `json:"color"`
+	WIPLimitMode string           `json:"wip_limit_mode"`
+	Labels       []ExportLabel    `json:"labels"`
+	Swimlanes    []ExportSwimlane `json:"swimlanes"`
+	Columns      []ExportColumn   `json:"columns"`
+}
+
+// ExportLabel represents a board label in an export document
+type ExportLabel struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// ExportSwimlane represents a swimlane in an export document
+type ExportSwimlane struct {
+	ID        uint   `json:"id"`
+	Title     string `json:"title"`
+	Collapsed bool   `json:"collapsed"`
+}
+
+// ExportColumn represents a column and its cards, in board order
+type ExportColumn struct {
+	Title    string       `json:"title"`
+	WIPLimit *int         `json:"wip_limit"`
+	Cards    []ExportCard `json:"cards"`
+}
+
+// ExportCard represents a card and its metadata. Assignees are user emails.
+type ExportCard struct {
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Priority    string            `json:"priority"`
+	StartAt     *time.Time        `json:"start_at"`
+	DueAt       *time.Time        `json:"due_at"`
+	SwimlaneID  *uint             `json:"swimlane_id"`
+	LabelIDs    []uint            `json:"label_ids"`
+	Assignees   []string          `json:"assignees"`
+	Checklists  []ExportChecklist `json:"checklists"`
+	Comments    []ExportComment   `json:"comments"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
+
+// ExportChecklist represents a card checklist and its items, in card order
+type ExportChecklist struct {
+	Title string                `json:"title"`
+	Items []ExportChecklistItem `json:"items"`
+}
+
+// ExportChecklistItem represents a checklist item in an export document
+type ExportChecklistItem struct {
+	Text string `json:"text"`
+	Done bool   `json:"done"`
+}
+
+// ExportComment represents a card comment. Author is the email of the user
+// who wrote it.
+type ExportComment struct {
+	Author    string     `json:"author"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"created_at"`
+	EditedAt  *time.Time `json:"edited_at"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+// CreateLabelRequest represents the request to create a label
+type CreateLabelRequest struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// UpdateLabelRequest represents the request to update a label
+type UpdateLabelRequest struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// LabelResponse represents label data in responses
+type LabelResponse struct {
+	ID      uint   `json:"id"`
+	Name    string `json:"name"`
+	Color   string `json:"color"`
+	BoardID uint   `json:"board_id"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+import "encoding/json"
+
+// Optional represents a nullable request field that distinguishes a missing
+// key from an explicit null. Set is true whenever the key was present; Value
+// is nil when the key was present but null.
+type Optional[T any] struct {
+	Set   bool
+	Value *T
+}
+
+// UnmarshalJSON is only invoked for keys present in the payload
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	if string(data) == "null" {
+		o.Value = nil
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	o.Value = &value
+	return nil
+}
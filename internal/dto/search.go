@@ -0,0 +1,18 @@
+package dto
+
+// SearchHitResponse represents a search result. Snippet is HTML-escaped text
+// with matched terms wrapped in <mark> tags. Column and card fields are empty
+// for board hits.
+type SearchHitResponse struct {
+	Type        string  `json:"type"`
+	ID          uint    `json:"id"`
+	Title       string  `json:"title"`
+	Snippet     string  `json:"snippet"`
+	Score       float64 `json:"score"`
+	BoardID     uint    `json:"board_id"`
+	BoardName   string  `json:"board_name"`
+	ColumnID    uint    `json:"column_id,omitempty"`
+	ColumnTitle string  `json:"column_title,omitempty"`
+	CardID      uint    `json:"card_id,omitempty"`
+	CardTitle   string  `json:"card_title,omitempty"`
+}
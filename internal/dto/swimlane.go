@@ -0,0 +1,35 @@
+package dto
+
+// CreateSwimlaneRequest represents the request to create a swimlane
+type CreateSwimlaneRequest struct {
+	Title string `json:"title"`
+}
+
+// UpdateSwimlaneRequest represents the request to rename, collapse or expand
+// a swimlane
+type UpdateSwimlaneRequest struct {
+	Title     string `json:"title"`
+	Collapsed *bool  `json:"collapsed"`
+}
+
+// ReorderSwimlanesRequest represents the request to reorder a board's swimlanes
+type ReorderSwimlanesRequest struct {
+	SwimlaneIDs []uint `json:"swimlane_ids"`
+}
+
+// SwimlaneResponse represents swimlane data in responses
+type SwimlaneResponse struct {
+	ID        uint                   `json:"id"`
+	Title     string                 `json:"title"`
+	Position  int                    `json:"position"`
+	Collapsed bool                   `json:"collapsed"`
+	BoardID   uint                   `json:"board_id"`
+	Cells     []SwimlaneCellResponse `json:"cells,omitempty"`
+}
+
+// SwimlaneCellResponse lists the cards of one column within a swimlane, in
+// column order
+type SwimlaneCellResponse struct {
+	ColumnID uint   `json:"column_id"`
+	CardIDs  []uint `json:"card_ids"`
+}
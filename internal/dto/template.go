@@ -0,0 +1,24 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// SaveTemplateRequest represents the request to save a board as a template
+type SaveTemplateRequest struct {
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	IncludeCards bool   `json:"include_cards"`
+}
+
+// TemplateResponse represents a board template in responses. Structure holds
+// the columns, WIP limits, labels, swimlanes and sample cards it creates.
+type TemplateResponse struct {
+	ID          uint            `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	BuiltIn     bool            `json:"built_in"`
+	Structure   json.RawMessage `json:"structure"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
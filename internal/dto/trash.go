@@ -0,0 +1,24 @@
+package dto
+
+import "time"
+
+// TrashItemResponse represents a deleted board, column or card. Board and
+// column context is included where it applies; PurgeAt is null when trash is
+// kept forever.
+type TrashItemResponse struct {
+	ID          uint       `json:"id"`
+	Title       string     `json:"title"`
+	BoardID     uint       `json:"board_id"`
+	BoardName   string     `json:"board_name,omitempty"`
+	ColumnID    uint       `json:"column_id,omitempty"`
+	ColumnTitle string     `json:"column_title,omitempty"`
+	DeletedAt   time.Time  `json:"deleted_at"`
+	PurgeAt     *time.Time `json:"purge_at"`
+}
+
+// TrashResponse represents the contents of the current user's trash
+type TrashResponse struct {
+	Boards  []TrashItemResponse `json:"boards"`
+	Columns []TrashItemResponse `json:"columns"`
+	Cards   []TrashItemResponse `json:"cards"`
+}
@@ -0,0 +1,108 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// TrelloBoard is the subset of a Trello board JSON export that is imported
+type TrelloBoard struct {
+	Name         string            `json:"name"`
+	Desc         string            `json:"desc"`
+	Prefs        TrelloPrefs       `json:"prefs"`
+	Labels       []TrelloLabel     `json:"labels"`
+	Lists        []TrelloList      `json:"lists"`
+	Cards        []TrelloCard      `json:"cards"`
+	Checklists   []TrelloChecklist `json:"checklists"`
+	Actions      []TrelloAction    `json:"actions"`
+	CustomFields []json.RawMessage `json:"customFields"`
+}
+
+// TrelloPrefs holds the board preferences that are imported
+type TrelloPrefs struct {
+	BackgroundColor string `json:"backgroundColor"`
+}
+
+// TrelloLabel is a Trello board label. Color is a Trello color name such as
+// "green" or "red_dark", or empty for colorless labels.
+type TrelloLabel struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// TrelloList is a Trello list, imported as a column
+type TrelloList struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Closed bool    `json:"closed"`
+	Pos    float64 `json:"pos"`
+}
+
+// TrelloCard is a Trello card
+type TrelloCard struct {
+	ID               string            `json:"id"`
+	Name             string            `json:"name"`
+	Desc             string            `json:"desc"`
+	Closed           bool              `json:"closed"`
+	IDList           string            `json:"idList"`
+	Pos              float64           `json:"pos"`
+	Start            *time.Time        `json:"start"`
+	Due              *time.Time        `json:"due"`
+	DueComplete      bool              `json:"dueComplete"`
+	IDLabels         []string          `json:"idLabels"`
+	IDMembers        []string          `json:"idMembers"`
+	Attachments      []json.RawMessage `json:"attachments"`
+	CustomFieldItems []json.RawMessage `json:"customFieldItems"`
+}
+
+// TrelloChecklist is a checklist on a Trello card
+type TrelloChecklist struct {
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	IDCard     string            `json:"idCard"`
+	Pos        float64           `json:"pos"`
+	CheckItems []TrelloCheckItem `json:"checkItems"`
+}
+
+// TrelloCheckItem is a checklist item; State is "complete" or "incomplete"
+type TrelloCheckItem struct {
+	Name  string  `json:"name"`
+	State string  `json:"state"`
+	Pos   float64 `json:"pos"`
+}
+
+// TrelloAction is an entry of a Trello board's action log. Only commentCard
+// actions are imported, as card comments.
+type TrelloAction struct {
+	Type string           `json:"type"`
+	Date time.Time        `json:"date"`
+	Data TrelloActionData `json:"data"`
+}
+
+// TrelloActionData holds the comment text and card of a commentCard action
+type TrelloActionData struct {
+	Text string `json:"text"`
+	Card struct {
+		ID string `json:"id"`
+	} `json:"card"`
+}
+
+// TrelloImportResponse reports what a Trello import created, or would create
+// in a dry run, and which Trello data could not be carried over
+type TrelloImportResponse struct {
+	DryRun  bool                `json:"dry_run"`
+	Board   *BoardResponse      `json:"board,omitempty"`
+	Created TrelloImportSummary `json:"created"`
+	Lost    []string            `json:"lost"`
+}
+
+// TrelloImportSummary counts the items an import creates
+type TrelloImportSummary struct {
+	Columns        int `json:"columns"`
+	Cards          int `json:"cards"`
+	Labels         int `json:"labels"`
+	Checklists     int `json:"checklists"`
+	ChecklistItems int `json:"checklist_items"`
+	Comments       int `json:"comments"`
+}
@@ -0,0 +1,106 @@
+package events
+
+import (
+	"sync"
+)
+
+// Event types published to board subscribers
+const (
+	BoardCreated        = "board.created"
+	BoardUpdated        = "board.updated"
+	BoardDeleted        = "board.deleted"
+	BoardRestored       = "board.restored"
+	ColumnCreated       = "column.created"
+	ColumnUpdated       = "column.updated"
+	ColumnDeleted       = "column.deleted"
+	ColumnRestored      = "column.restored"
+	ColumnsReordered    = "columns.reordered"
+	CardCreated         = "card.created"
+	CardUpdated         = "card.updated"
+	CardMoved           = "card.moved"
+	CardDeleted         = "card.deleted"
+	CardRestored        = "card.restored"
+	CardsReordered      = "cards.reordered"
+	LabelCreated        = "label.created"
+	LabelUpdated        = "label.updated"
+	LabelDeleted        = "label.deleted"
+	CommentCreated      = "comment.created"
+	CommentUpdated      = "comment.updated"
+	CommentDeleted      = "comment.deleted"
+	ChecklistCreated    = "checklist.created"
+	ChecklistUpdated    = "checklist.updated"
+	ChecklistDeleted    = "checklist.deleted"
+	ChecklistsReordered = "checklists.reordered"
+	AttachmentCreated   = "attachment.created"
+	AttachmentDeleted   = "attachment.deleted"
+	SwimlaneCreated     = "swimlane.created"
+	SwimlaneUpdated     = "swimlane.updated"
+	SwimlaneDeleted     = "swimlane.deleted"
+	SwimlanesReordered  = "swimlanes.reordered"
+)
+
+// subscriberBuffer is how many events a slow subscriber may lag behind before
+// further events are dropped for it
+const subscriberBuffer = 32
+
+// Event represents a change on a board
+type Event struct {
+	Type    string      `json:"type"`
+	BoardID uint        `json:"board_id"`
+	ActorID uint        `json:"actor_id"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// Broker fans out board events to in-process subscribers
+type Broker struct {
+	mu          sync.RWMutex
+	subscribers map[uint]map[chan Event]struct{}
+}
+
+func NewBroker() *Broker {
+	return &Broker{
+		subscribers: make(map[uint]map[chan Event]struct{}),
+	}
+}
+
+// Subscribe registers a listener for a board's events. The returned function
+// must be called to release the subscription.
+func (b *Broker) Subscribe(boardID uint) (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	b.mu.Lock()
+	if b.subscribers[boardID] == nil {
+		b.subscribers[boardID] = make(map[chan Event]struct{})
+	}
+	b.subscribers[boardID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers[boardID], ch)
+			if len(b.subscribers[boardID]) == 0 {
+				delete(b.subscribers, boardID)
+			}
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// Publish sends an event to every subscriber of the event's board without
+// blocking; subscribers whose buffer is full miss the event
+func (b *Broker) Publish(event Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers[event.BoardID] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
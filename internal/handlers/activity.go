@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/icl00ud/goban/internal/dto"
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/services"
+	"github.com/icl00ud/goban/internal/utils"
+)
+
+type ActivityHandler struct {
+	activityService *services.ActivityService
+}
+
+func NewActivityHandler(activityService *services.ActivityService) *ActivityHandler {
+	return &ActivityHandler{activityService: activityService}
+}
+
+// ListForBoard returns a page of a board's activity
+func (h *ActivityHandler) ListForBoard(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	boardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board ID")
+	}
+
+	cursor, limit, ok := parsePage(c)
+	if !ok {
+		return utils.BadRequest(c, "cursor and limit must be positive numbers")
+	}
+
+	activities, next, err := h.activityService.ListForBoard(uint(boardID), userID, cursor, limit)
+	if err != nil {
+		return activityError(c, err)
+	}
+
+	return utils.Success(c, toActivityPageResponse(activities, next))
+}
+
+// ListForCard returns a page of a card's activity
+func (h *ActivityHandler) ListForCard(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	cardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card ID")
+	}
+
+	cursor, limit, ok := parsePage(c)
+	if !ok {
+		return utils.BadRequest(c, "cursor and limit must be positive numbers")
+	}
+
+	activities, next, err := h.activityService.ListForCard(uint(cardID), userID, cursor, limit)
+	if err != nil {
+		return activityError(c, err)
+	}
+
+	return utils.Success(c, toActivityPageResponse(activities, next))
+}
+
+// parsePage reads the cursor and limit query parameters
+func parsePage(c *fiber.Ctx) (uint, int, bool) {
+	cursor := c.QueryInt("cursor", 0)
+	limit := c.QueryInt("limit", 0)
+	if cursor < 0 || limit < 0 {
+		return 0, 0, false
+	}
+	return uint(cursor), limit, true
+}
+
+// activityError maps activity service errors to responses
+func activityError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
+		return utils.Forbidden(c, err.Error())
+	}
+	if errors.Is(err, services.ErrCardNotFound) {
+		return utils.NotFound(c, err.Error())
+	}
+	return utils.InternalError(c, "Failed to fetch activity")
+}
+
+// toActivityPageResponse converts a page of Activity models to ActivityPageResponse DTO
+func toActivityPageResponse(activities []models.Activity, next uint) dto.ActivityPageResponse {
+	response := dto.ActivityPageResponse{
+		Items: make([]dto.ActivityResponse, len(activities)),
+	}
+	if next != 0 {
+		response.NextCursor = &next
+	}
+
+	for i, activity := range activities {
+		response.Items[i] = dto.ActivityResponse{
+			ID:         activity.ID,
+			BoardID:    activity.BoardID,
+			Actor:      toUserResponse(&activity.User),
+			EntityType: activity.EntityType,
+			EntityID:   activity.EntityID,
+			Action:     activity.Action,
+			Before:     rawSnapshot(activity.Before),
+			After:      rawSnapshot(activity.After),
+			CreatedAt:  activity.CreatedAt,
+		}
+	}
+
+	return response
+}
+
+// rawSnapshot embeds a stored JSON snapshot, using null when there is none
+func rawSnapshot(snapshot string) json.RawMessage {
+	if snapshot == "" {
+		return nil
+	}
+	return json.RawMessage(snapshot)
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/icl00ud/goban/internal/dto"
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/services"
+	"github.com/icl00ud/goban/internal/utils"
+)
+
+type AdminHandler struct {
+	adminService *services.AdminService
+}
+
+func NewAdminHandler(adminService *services.AdminService) *AdminHandler {
+	return &AdminHandler{adminService: adminService}
+}
+
+// ListUsers returns every user, optionally filtered with ?q= by name or email
+func (h *AdminHandler) ListUsers(c *fiber.Ctx) error {
+	users, err := h.adminService.ListUsers(c.Query("q"))
+	if err != nil {
+		return utils.InternalError(c, "Failed to fetch users")
+	}
+
+	response := make([]dto.AdminUserResponse, len(users))
+	for i := range users {
+		response[i] = toAdminUserResponse(&users[i])
+	}
+
+	return utils.Success(c, response)
+}
+
+// UpdateUser grants or revokes the administrator role and disables or
+// enables a user
+func (h *AdminHandler) UpdateUser(c *fiber.Ctx) error {
+	actorID := c.Locals("userID").(uint)
+	userID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid user ID")
+	}
+
+	var req dto.AdminUpdateUserRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+
+	user, err := h.adminService.UpdateUser(actorID, uint(userID), req.IsAdmin, req.Disabled)
+	if err != nil {
+		return adminError(c, err, "Failed to update user")
+	}
+
+	return utils.Success(c, toAdminUserResponse(user))
+}
+
+// DeleteUser deletes a user and sends the boards they created to the trash
+func (h *AdminHandler) DeleteUser(c *fiber.Ctx) error {
+	actorID := c.Locals("userID").(uint)
+	userID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid user ID")
+	}
+
+	if err := h.adminService.DeleteUser(actorID, uint(userID)); err != nil {
+		return adminError(c, err, "Failed to delete user")
+	}
+
+	return utils.SuccessWithMessage(c, "User deleted")
+}
+
+// ResetPassword sets a user's password, or emails them a reset link when no
+// password is given
+func (h *AdminHandler) ResetPassword(c *fiber.Ctx) error {
+	userID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid user ID")
+	}
+
+	var req dto.AdminResetPasswordRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.BadRequest(c, "Invalid request body")
+		}
+	}
+	if req.Password != "" {
+		if err := validatePassword(req.Password); err != nil {
+			return utils.BadRequest(c, err.Error())
+		}
+	}
+
+	emailed, err := h.adminService.ResetPassword(uint(userID), req.Password)
+	if err != nil {
+		return adminError(c, err, "Failed to reset password")
+	}
+
+	if emailed {
+		return utils.SuccessWithMessage(c, "Password reset link sent")
+	}
+	return utils.SuccessWithMessage(c, "Password reset")
+}
+
+// ListInvites returns every invite
+func (h *AdminHandler) ListInvites(c *fiber.Ctx) error {
+	invites, err := h.adminService.ListInvites()
+	if err != nil {
+		return utils.InternalError(c, "Failed to fetch invites")
+	}
+
+	response := make([]dto.InviteResponse, len(invites))
+	for i := range invites {
+		response[i] = toInviteResponse(&invites[i])
+	}
+
+	return utils.Success(c, response)
+}
+
+// CreateInvite issues an invite and returns its code and link once
+func (h *AdminHandler) CreateInvite(c *fiber.Ctx) error {
+	actorID := c.Locals("userID").(uint)
+
+	var req dto.CreateInviteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+	req.Email = strings.TrimSpace(req.Email)
+	if req.Email != "" && !strings.Contains(req.Email, "@") {
+		return utils.BadRequest(c, "invalid email format")
+	}
+
+	invite, code, err := h.adminService.CreateInvite(actorID, req.Email, req.ExpiresAt)
+	if err != nil {
+		return adminError(c, err, "Failed to create invite")
+	}
+
+	return utils.Created(c, dto.CreatedInviteResponse{
+		InviteResponse: toInviteResponse(invite),
+		Code:           code,
+		Link:           h.adminService.InviteLink(code),
+	})
+}
+
+// RevokeInvite deletes an invite
+func (h *AdminHandler) RevokeInvite(c *fiber.Ctx) error {
+	inviteID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid invite ID")
+	}
+
+	if err := h.adminService.RevokeInvite(uint(inviteID)); err != nil {
+		return adminError(c, err, "Failed to revoke invite")
+	}
+
+	return utils.SuccessWithMessage(c, "Invite revoked")
+}
+
+// adminError maps administration service errors to responses
+func adminError(c *fiber.Ctx, err error, fallback string) error {
+	if errors.Is(err, services.ErrUserNotFound) || errors.Is(err, services.ErrInviteNotFound) {
+		return utils.NotFound(c, err.Error())
+	}
+	if errors.Is(err, services.ErrCannotModifySelf) || errors.Is(err, services.ErrInviteExpiryInPast) {
+		return utils.BadRequest(c, err.Error())
+	}
+	return utils.InternalError(c, fallback)
+}
+
+// toAdminUserResponse converts a User model to AdminUserResponse DTO
+func toAdminUserResponse(user *models.User) dto.AdminUserResponse {
+	return dto.AdminUserResponse{
+		ID:               user.ID,
+		Email:            user.Email,
+		Name:             user.Name,
+		IsAdmin:          user.IsAdmin,
+		DisabledAt:       user.DisabledAt,
+		EmailVerified:    user.EmailVerifiedAt != nil,
+		TwoFactorEnabled: user.TOTPEnabled,
+		CreatedAt:        user.CreatedAt,
+	}
+}
+
+// toInviteResponse converts an Invite model to InviteResponse DTO
+func toInviteResponse(invite *models.Invite) dto.InviteResponse {
+	response := dto.InviteResponse{
+		ID:        invite.ID,
+		Email:     invite.Email,
+		ExpiresAt: invite.ExpiresAt,
+		UsedAt:    invite.UsedAt,
+		CreatedBy: invite.CreatedBy.Name,
+		CreatedAt: invite.CreatedAt,
+	}
+	if invite.UsedBy != nil {
+		response.UsedBy = &invite.UsedBy.Name
+	}
+	return response
+}
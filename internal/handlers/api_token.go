@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/icl00ud/goban/internal/dto"
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/services"
+	"github.com/icl00ud/goban/internal/utils"
+)
+
+type APITokenHandler struct {
+	tokenService *services.APITokenService
+}
+
+func NewAPITokenHandler(tokenService *services.APITokenService) *APITokenHandler {
+	return &APITokenHandler{tokenService: tokenService}
+}
+
+// List returns the current user's API tokens
+func (h *APITokenHandler) List(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	tokens, err := h.tokenService.List(userID)
+	if err != nil {
+		return utils.InternalError(c, "Failed to fetch API tokens")
+	}
+
+	response := make([]dto.APITokenResponse, len(tokens))
+	for i := range tokens {
+		response[i] = toAPITokenResponse(&tokens[i])
+	}
+
+	return utils.Success(c, response)
+}
+
+// Create issues a new API token and returns its secret once
+func (h *APITokenHandler) Create(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	var req dto.CreateAPITokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+
+	token, secret, err := h.tokenService.Create(userID, req.Name, req.Scope, req.ExpiresAt)
+	if err != nil {
+		return apiTokenError(c, err, "Failed to create API token")
+	}
+
+	return utils.Created(c, dto.CreatedAPITokenResponse{
+		APITokenResponse: toAPITokenResponse(token),
+		Token:            secret,
+	})
+}
+
+// Revoke deletes one of the current user's API tokens
+func (h *APITokenHandler) Revoke(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	tokenID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid token ID")
+	}
+
+	if err := h.tokenService.Revoke(uint(tokenID), userID); err != nil {
+		return apiTokenError(c, err, "Failed to revoke API token")
+	}
+
+	return utils.SuccessWithMessage(c, "API token revoked")
+}
+
+// apiTokenError maps API token service errors to responses
+func apiTokenError(c *fiber.Ctx, err error, fallback string) error {
+	if errors.Is(err, services.ErrAPITokenNotFound) {
+		return utils.NotFound(c, err.Error())
+	}
+	if errors.Is(err, services.ErrAPITokenNameRequired) || errors.Is(err, services.ErrAPITokenNameTooLong) ||
+		errors.Is(err, services.ErrInvalidTokenScope) || errors.Is(err, services.ErrTokenExpiryInPast) {
+		return utils.BadRequest(c, err.Error())
+	}
+	return utils.InternalError(c, fallback)
+}
+
+// toAPITokenResponse converts an APIToken model to APITokenResponse DTO
+func toAPITokenResponse(token *models.APIToken) dto.APITokenResponse {
+	return dto.APITokenResponse{
+		ID:         token.ID,
+		Name:       token.Name,
+		Prefix:     token.Prefix,
+		Scope:      token.Scope,
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		CreatedAt:  token.CreatedAt,
+	}
+}
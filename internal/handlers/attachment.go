@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"errors"
+	"mime"
+	"path/filepath"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/icl00ud/goban/internal/dto"
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/services"
+	"github.com/icl00ud/goban/internal/utils"
+)
+
+type AttachmentHandler struct {
+	attachmentService *services.AttachmentService
+}
+
+func NewAttachmentHandler(attachmentService *services.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{attachmentService: attachmentService}
+}
+
+// List returns all attachments of a card
+func (h *AttachmentHandler) List(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	cardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card ID")
+	}
+
+	attachments, err := h.attachmentService.List(uint(cardID), userID)
+	if err != nil {
+		return attachmentError(c, err, "Failed to fetch attachments")
+	}
+
+	response := make([]dto.AttachmentResponse, len(attachments))
+	for i, attachment := range attachments {
+		response[i] = toAttachmentResponse(&attachment)
+	}
+
+	return utils.Success(c, response)
+}
+
+// Upload attaches the multipart "file" field to a card
+func (h *AttachmentHandler) Upload(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	cardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card ID")
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return utils.BadRequest(c, "A file is required in the \"file\" form field")
+	}
+
+	fileName := filepath.Base(filepath.Clean("/" + fileHeader.Filename))
+	if fileName == "/" || fileName == "." {
+		return utils.BadRequest(c, "Invalid file name")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return utils.BadRequest(c, "Invalid file")
+	}
+	defer file.Close()
+
+	attachment, err := h.attachmentService.Upload(uint(cardID), userID, fileName, fileHeader.Header.Get("Content-Type"), fileHeader.Size, file)
+	if err != nil {
+		return attachmentError(c, err, "Failed to upload attachment")
+	}
+
+	return utils.Created(c, toAttachmentResponse(attachment))
+}
+
+// Download streams the contents of an attachment
+func (h *AttachmentHandler) Download(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	cardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card ID")
+	}
+	attachmentID, err := c.ParamsInt("attachmentId")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid attachment ID")
+	}
+
+	attachment, contents, err := h.attachmentService.Open(uint(cardID), uint(attachmentID), userID)
+	if err != nil {
+		return attachmentError(c, err, "Failed to download attachment")
+	}
+
+	// Always download rather than render, so uploaded HTML or SVG cannot run in the app's origin
+	c.Set(fiber.HeaderContentType, attachment.ContentType)
+	c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	c.Set(fiber.HeaderContentSecurityPolicy, "sandbox")
+
+	return c.SendStream(contents, int(attachment.Size))
+}
+
+// Delete removes an attachment from a card
+func (h *AttachmentHandler) Delete(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	cardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card ID")
+	}
+	attachmentID, err := c.ParamsInt("attachmentId")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid attachment ID")
+	}
+
+	if err := h.attachmentService.Delete(uint(cardID), uint(attachmentID), userID); err != nil {
+		return attachmentError(c, err, "Failed to delete attachment")
+	}
+
+	return utils.SuccessWithMessage(c, "Attachment deleted successfully")
+}
+
+// attachmentError maps attachment service errors to responses
+func attachmentError(c *fiber.Ctx, err error, fallback string) error {
+	if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
+		return utils.Forbidden(c, err.Error())
+	}
+	if errors.Is(err, services.ErrAttachmentNotFound) || errors.Is(err, services.ErrCardNotFound) {
+		return utils.NotFound(c, err.Error())
+	}
+	if errors.Is(err, services.ErrAttachmentEmpty) || errors.Is(err, services.ErrAttachmentTooLarge) || errors.Is(err, services.ErrAttachmentTypeForbidden) {
+		return utils.BadRequest(c, err.Error())
+	}
+	return utils.InternalError(c, fallback)
+}
+
+// toAttachmentResponse converts an Attachment model to AttachmentResponse DTO
+func toAttachmentResponse(attachment *models.Attachment) dto.AttachmentResponse {
+	return dto.AttachmentResponse{
+		ID:          attachment.ID,
+		CardID:      attachment.CardID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Uploader:    toUserResponse(&attachment.User),
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
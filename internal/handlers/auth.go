@@ -2,23 +2,40 @@ package handlers
 
 import (
 	"errors"
+	"log"
+	"math"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/icl00ud/goban/internal/dto"
+	"github.com/icl00ud/goban/internal/models"
 	"github.com/icl00ud/goban/internal/services"
 	"github.com/icl00ud/goban/internal/utils"
 )
 
-const CookieName = "goban_token"
+// Session cookies. The refresh cookie is only sent to the auth endpoints.
+const (
+	CookieName        = "goban_token"
+	RefreshCookieName = "goban_refresh"
+	refreshCookiePath = "/api/v1/auth"
+)
 
 type AuthHandler struct {
-	authService *services.AuthService
+	authService      *services.AuthService
+	sessionService   *services.SessionService
+	twoFactorService *services.TwoFactorService
+	accountService   *services.AccountService
 }
 
-func NewAuthHandler(authService *services.AuthService) *AuthHandler {
-	return &AuthHandler{authService: authService}
+func NewAuthHandler(authService *services.AuthService, sessionService *services.SessionService, twoFactorService *services.TwoFactorService, accountService *services.AccountService) *AuthHandler {
+	return &AuthHandler{
+		authService:      authService,
+		sessionService:   sessionService,
+		twoFactorService: twoFactorService,
+		accountService:   accountService,
+	}
 }
 
 // Register handles user registration
@@ -36,20 +53,41 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 	// Register user
 	user, err := h.authService.Register(&req)
 	if err != nil {
-		if errors.Is(err, services.ErrUserExists) {
+		if errors.Is(err, services.ErrUserExists) || errors.Is(err, services.ErrInviteRequired) ||
+			errors.Is(err, services.ErrInvalidInvite) {
 			return utils.BadRequest(c, err.Error())
 		}
+		if errors.Is(err, services.ErrRegistrationClosed) || errors.Is(err, services.ErrEmailDomainNotAllowed) {
+			return utils.Forbidden(c, err.Error())
+		}
 		return utils.InternalError(c, "Failed to create user")
 	}
 
-	return utils.Created(c, dto.UserResponse{
-		ID:    user.ID,
-		Email: user.Email,
-		Name:  user.Name,
+	// The account works right away; verifying the address can happen later
+	if err := h.accountService.SendVerification(user); err != nil {
+		log.Printf("Failed to send verification email to user %d: %v", user.ID, err)
+	}
+
+	return utils.Created(c, toUserResponse(user))
+}
+
+// RegistrationPolicy tells the registration form whether it needs an invite
+// code and which email domains may sign up
+func (h *AuthHandler) RegistrationPolicy(c *fiber.Ctx) error {
+	policy := h.authService.RegistrationPolicy()
+	allowedDomains := policy.AllowedDomains
+	if allowedDomains == nil {
+		allowedDomains = []string{}
+	}
+
+	return utils.Success(c, dto.RegistrationPolicyResponse{
+		Mode:           policy.Mode,
+		AllowedDomains: allowedDomains,
 	})
 }
 
-// Login handles user login
+// Login handles user login. Users with two-factor authentication get a
+// challenge to complete with LoginTwoFactor instead of a session.
 func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	var req dto.LoginRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -62,44 +100,105 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	}
 
 	// Authenticate user
-	user, token, err := h.authService.Login(&req)
+	user, err := h.authService.Login(&req)
 	if err != nil {
 		if errors.Is(err, services.ErrInvalidCredentials) {
 			return utils.Unauthorized(c, err.Error())
 		}
+		if errors.Is(err, services.ErrAccountDisabled) {
+			return utils.Forbidden(c, err.Error())
+		}
+		var locked *services.AccountLockedError
+		if errors.As(err, &locked) {
+			return accountLocked(c, locked)
+		}
 		return utils.InternalError(c, "Login failed")
 	}
 
-	// Set HTTPOnly cookie
-	c.Cookie(&fiber.Cookie{
-		Name:     CookieName,
-		Value:    token,
-		Expires:  time.Now().Add(utils.TokenExpiration),
-		HTTPOnly: true,
-		Secure:   false, // Set to true in production with HTTPS
-		SameSite: "Lax",
-		Path:     "/",
-	})
+	if user.TOTPEnabled {
+		challenge, expiresAt, err := h.twoFactorService.StartChallenge(user)
+		if err != nil {
+			var locked *services.AccountLockedError
+			if errors.As(err, &locked) {
+				return accountLocked(c, locked)
+			}
+			return utils.InternalError(c, "Login failed")
+		}
+		return utils.Success(c, dto.LoginChallengeResponse{
+			TwoFactorRequired: true,
+			Challenge:         challenge,
+			ExpiresAt:         expiresAt,
+		})
+	}
 
-	return utils.Success(c, dto.UserResponse{
-		ID:    user.ID,
-		Email: user.Email,
-		Name:  user.Name,
-	})
+	return h.startSession(c, user)
+}
+
+// LoginTwoFactor completes a login challenge with a TOTP or recovery code
+func (h *AuthHandler) LoginTwoFactor(c *fiber.Ctx) error {
+	var req dto.TwoFactorLoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+	if req.Challenge == "" || req.Code == "" {
+		return utils.BadRequest(c, "Challenge and code are required")
+	}
+
+	user, err := h.twoFactorService.CompleteChallenge(req.Challenge, req.Code)
+	if err != nil {
+		return twoFactorError(c, err, "Login failed")
+	}
+
+	return h.startSession(c, user)
+}
+
+// accountLocked responds that the account is locked, saying when to retry
+func accountLocked(c *fiber.Ctx, locked *services.AccountLockedError) error {
+	retryAfter := int(math.Ceil(time.Until(locked.Until).Seconds()))
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(max(retryAfter, 1)))
+	return utils.Error(c, fiber.StatusTooManyRequests, locked.Error())
+}
+
+// startSession starts a session for this device and responds with the user
+func (h *AuthHandler) startSession(c *fiber.Ctx, user *models.User) error {
+	tokens, err := h.sessionService.Start(user, c.Get(fiber.HeaderUserAgent), clientIP(c))
+	if err != nil {
+		return utils.InternalError(c, "Login failed")
+	}
+	setSessionCookies(c, tokens)
+
+	return utils.Success(c, toUserResponse(user))
+}
+
+// Refresh rotates the session's refresh token and issues a new access token
+func (h *AuthHandler) Refresh(c *fiber.Ctx) error {
+	refreshToken := c.Cookies(RefreshCookieName)
+	if refreshToken == "" {
+		return utils.Unauthorized(c, "Authentication required")
+	}
+
+	tokens, user, err := h.sessionService.Refresh(refreshToken, c.Get(fiber.HeaderUserAgent), clientIP(c))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidRefreshToken) {
+			clearSessionCookies(c)
+			return utils.Unauthorized(c, err.Error())
+		}
+		return utils.InternalError(c, "Failed to refresh session")
+	}
+	setSessionCookies(c, tokens)
+
+	return utils.Success(c, toUserResponse(user))
 }
 
 // Logout handles user logout
 func (h *AuthHandler) Logout(c *fiber.Ctx) error {
-	// Clear the cookie
-	c.Cookie(&fiber.Cookie{
-		Name:     CookieName,
-		Value:    "",
-		Expires:  time.Now().Add(-time.Hour),
-		HTTPOnly: true,
-		Secure:   false,
-		SameSite: "Lax",
-		Path:     "/",
-	})
+	// Revoke the session so its tokens stop working everywhere
+	if err := h.sessionService.End(c.Cookies(RefreshCookieName), c.Cookies(CookieName)); err != nil {
+		return utils.InternalError(c, "Logout failed")
+	}
+
+	// Clear the cookies
+	clearSessionCookies(c)
 
 	return utils.SuccessWithMessage(c, "Logged out successfully")
 }
@@ -113,13 +212,147 @@ func (h *AuthHandler) Me(c *fiber.Ctx) error {
 		return utils.NotFound(c, "User not found")
 	}
 
-	return utils.Success(c, dto.UserResponse{
-		ID:    user.ID,
-		Email: user.Email,
-		Name:  user.Name,
+	return utils.Success(c, toUserResponse(user))
+}
+
+// ForgotPassword emails a password reset link. The response is the same
+// whether or not an account exists for the address.
+func (h *AuthHandler) ForgotPassword(c *fiber.Ctx) error {
+	var req dto.ForgotPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+
+	req.Email = strings.TrimSpace(req.Email)
+	if req.Email == "" {
+		return utils.BadRequest(c, "Email is required")
+	}
+
+	if err := h.accountService.ForgotPassword(req.Email); err != nil {
+		return utils.InternalError(c, "Failed to send password reset email")
+	}
+
+	return utils.SuccessWithMessage(c, "If an account exists for that email, a password reset link has been sent")
+}
+
+// ResetPassword sets a new password with an emailed token and signs out
+// every session of the user
+func (h *AuthHandler) ResetPassword(c *fiber.Ctx) error {
+	var req dto.ResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+	if req.Token == "" {
+		return utils.BadRequest(c, "Token is required")
+	}
+	if err := validatePassword(req.Password); err != nil {
+		return utils.BadRequest(c, err.Error())
+	}
+
+	if err := h.accountService.ResetPassword(req.Token, req.Password); err != nil {
+		return accountError(c, err, "Failed to reset password")
+	}
+	clearSessionCookies(c)
+
+	return utils.SuccessWithMessage(c, "Password reset successfully")
+}
+
+// VerifyEmail verifies the user's email address with an emailed token
+func (h *AuthHandler) VerifyEmail(c *fiber.Ctx) error {
+	var req dto.VerifyEmailRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+	if req.Token == "" {
+		return utils.BadRequest(c, "Token is required")
+	}
+
+	user, err := h.accountService.VerifyEmail(req.Token)
+	if err != nil {
+		return accountError(c, err, "Failed to verify email")
+	}
+
+	return utils.Success(c, toUserResponse(user))
+}
+
+// ResendVerification emails the current user a new verification link
+func (h *AuthHandler) ResendVerification(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	if err := h.accountService.ResendVerification(userID); err != nil {
+		return accountError(c, err, "Failed to send verification email")
+	}
+
+	return utils.SuccessWithMessage(c, "Verification email sent")
+}
+
+// accountError maps password reset and verification errors to responses
+func accountError(c *fiber.Ctx, err error, fallback string) error {
+	if errors.Is(err, services.ErrEmailAlreadyVerified) {
+		return utils.Error(c, fiber.StatusConflict, err.Error())
+	}
+	if errors.Is(err, services.ErrInvalidUserToken) {
+		return utils.BadRequest(c, err.Error())
+	}
+	return utils.InternalError(c, fallback)
+}
+
+// clientIP returns the client address found by the ClientIP middleware
+func clientIP(c *fiber.Ctx) string {
+	if ip, ok := c.Locals("clientIP").(string); ok {
+		return ip
+	}
+	return c.IP()
+}
+
+// setSessionCookies stores a session's access and refresh tokens in HTTPOnly cookies
+func setSessionCookies(c *fiber.Ctx, tokens *services.SessionTokens) {
+	c.Cookie(&fiber.Cookie{
+		Name:     CookieName,
+		Value:    tokens.AccessToken,
+		Expires:  time.Now().Add(utils.AccessTokenExpiration),
+		HTTPOnly: true,
+		Secure:   false, // Set to true in production with HTTPS
+		SameSite: "Lax",
+		Path:     "/",
+	})
+	c.Cookie(&fiber.Cookie{
+		Name:     RefreshCookieName,
+		Value:    tokens.RefreshToken,
+		Expires:  tokens.Session.ExpiresAt,
+		HTTPOnly: true,
+		Secure:   false,
+		SameSite: "Lax",
+		Path:     refreshCookiePath,
 	})
 }
 
+// clearSessionCookies removes the access and refresh cookies
+func clearSessionCookies(c *fiber.Ctx) {
+	for _, cookie := range []struct{ name, path string }{{CookieName, "/"}, {RefreshCookieName, refreshCookiePath}} {
+		c.Cookie(&fiber.Cookie{
+			Name:     cookie.name,
+			Value:    "",
+			Expires:  time.Now().Add(-time.Hour),
+			HTTPOnly: true,
+			Secure:   false,
+			SameSite: "Lax",
+			Path:     cookie.path,
+		})
+	}
+}
+
+// toUserResponse converts a User model to UserResponse DTO
+func toUserResponse(user *models.User) dto.UserResponse {
+	return dto.UserResponse{
+		ID:            user.ID,
+		Email:         user.Email,
+		Name:          user.Name,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		IsAdmin:       user.IsAdmin,
+	}
+}
+
 // validateRegisterRequest validates registration request fields
 func validateRegisterRequest(req *dto.RegisterRequest) error {
 	req.Email = strings.TrimSpace(req.Email)
@@ -131,14 +364,22 @@ func validateRegisterRequest(req *dto.RegisterRequest) error {
 	if !strings.Contains(req.Email, "@") {
 		return errors.New("invalid email format")
 	}
-	if req.Password == "" {
-		return errors.New("password is required")
-	}
-	if len(req.Password) < 6 {
-		return errors.New("password must be at least 6 characters")
+	if err := validatePassword(req.Password); err != nil {
+		return err
 	}
 	if req.Name == "" {
 		return errors.New("name is required")
 	}
 	return nil
 }
+
+// validatePassword checks a new password against the password rules
+func validatePassword(password string) error {
+	if password == "" {
+		return errors.New("password is required")
+	}
+	if len(password) < 6 {
+		return errors.New("password must be at least 6 characters")
+	}
+	return nil
+}
@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"errors"
+	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/icl00ud/goban/internal/dto"
 	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/repository"
 	"github.com/icl00ud/goban/internal/services"
 	"github.com/icl00ud/goban/internal/utils"
 )
@@ -47,16 +50,23 @@ func (h *BoardHandler) Create(c *fiber.Ctx) error {
 	if req.Name == "" {
 		return utils.BadRequest(c, "Board name is required")
 	}
+	if req.Color != "" && !models.ValidateColor(req.Color) {
+		return utils.BadRequest(c, services.ErrInvalidColor.Error())
+	}
 
-	board, err := h.boardService.Create(userID, req.Name, req.Description, req.Color)
+	board, err := h.boardService.Create(userID, req.Name, req.Description, req.Color, req.TemplateID)
 	if err != nil {
+		if errors.Is(err, services.ErrTemplateNotFound) {
+			return utils.NotFound(c, err.Error())
+		}
 		return utils.InternalError(c, "Failed to create board")
 	}
 
 	return utils.Created(c, toBoardResponse(board))
 }
 
-// Get retrieves a single board with its columns and cards
+// Get retrieves a single board with its columns and cards, optionally
+// filtered to cards carrying any of the ?labels=1,2 label IDs
 func (h *BoardHandler) Get(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(uint)
 	boardID, err := c.ParamsInt("id")
@@ -64,9 +74,14 @@ func (h *BoardHandler) Get(c *fiber.Ctx) error {
 		return utils.BadRequest(c, "Invalid board ID")
 	}
 
-	board, err := h.boardService.GetByID(uint(boardID), userID)
+	labelIDs, err := parseIDList(c.Query("labels"))
+	if err != nil {
+		return utils.BadRequest(c, "Invalid label IDs")
+	}
+
+	board, err := h.boardService.GetByID(uint(boardID), userID, labelIDs)
 	if err != nil {
-		if errors.Is(err, services.ErrNotBoardOwner) {
+		if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
 			return utils.Forbidden(c, err.Error())
 		}
 		if errors.Is(err, services.ErrBoardNotFound) {
@@ -91,27 +106,71 @@ func (h *BoardHandler) Update(c *fiber.Ctx) error {
 		return utils.BadRequest(c, "Invalid request body")
 	}
 
-	board, err := h.boardService.Update(uint(boardID), userID, req.Name, req.Description, req.Color)
+	if req.Color != "" && !models.ValidateColor(req.Color) {
+		return utils.BadRequest(c, services.ErrInvalidColor.Error())
+	}
+
+	board, err := h.boardService.Update(uint(boardID), userID, req.Name, req.Description, req.Color, req.WIPLimitMode)
 	if err != nil {
-		if errors.Is(err, services.ErrNotBoardOwner) {
+		if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
 			return utils.Forbidden(c, err.Error())
 		}
 		if errors.Is(err, services.ErrBoardNotFound) {
 			return utils.NotFound(c, err.Error())
 		}
+		if errors.Is(err, services.ErrInvalidWIPLimitMode) {
+			return utils.BadRequest(c, err.Error())
+		}
 		return utils.InternalError(c, "Failed to update board")
 	}
 
 	return utils.Success(c, dto.BoardResponse{
-		ID:          board.ID,
-		Name:        board.Name,
-		Description: board.Description,
-		Color:       board.Color,
-		Position:    board.Position,
-		CreatedAt:   board.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		ID:           board.ID,
+		Name:         board.Name,
+		Description:  board.Description,
+		Color:        board.Color,
+		Position:     board.Position,
+		WIPLimitMode: board.WIPLimitMode,
+		CreatedAt:    board.CreatedAt.Format("2006-01-02T15:04:05Z"),
 	})
 }
 
+// Duplicate copies a board into a new board owned by the authenticated user
+func (h *BoardHandler) Duplicate(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	boardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board ID")
+	}
+
+	var req dto.DuplicateBoardRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.BadRequest(c, "Invalid request body")
+		}
+	}
+
+	board, err := h.boardService.Duplicate(uint(boardID), userID, strings.TrimSpace(req.Name), repository.DuplicateOptions{
+		Cards:      req.IncludeCards,
+		Labels:     req.IncludeLabels,
+		Checklists: req.IncludeChecklists,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
+			return utils.Forbidden(c, err.Error())
+		}
+		if errors.Is(err, services.ErrBoardNotFound) {
+			return utils.NotFound(c, err.Error())
+		}
+		if errors.Is(err, services.ErrDuplicateChecklistsWithoutCards) {
+			return utils.BadRequest(c, err.Error())
+		}
+		return utils.InternalError(c, "Failed to duplicate board")
+	}
+
+	return utils.Created(c, toBoardResponse(board))
+}
+
 // Reorder reorders boards for the authenticated user
 func (h *BoardHandler) Reorder(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(uint)
@@ -127,7 +186,7 @@ func (h *BoardHandler) Reorder(c *fiber.Ctx) error {
 
 	err := h.boardService.Reorder(userID, req.BoardIDs)
 	if err != nil {
-		if errors.Is(err, services.ErrNotBoardOwner) {
+		if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
 			return utils.Forbidden(c, err.Error())
 		}
 		return utils.InternalError(c, "Failed to reorder boards")
@@ -146,7 +205,7 @@ func (h *BoardHandler) Delete(c *fiber.Ctx) error {
 
 	err = h.boardService.Delete(uint(boardID), userID)
 	if err != nil {
-		if errors.Is(err, services.ErrNotBoardOwner) {
+		if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
 			return utils.Forbidden(c, err.Error())
 		}
 		return utils.InternalError(c, "Failed to delete board")
@@ -158,12 +217,13 @@ func (h *BoardHandler) Delete(c *fiber.Ctx) error {
 // toBoardResponse converts a Board model to BoardResponse DTO
 func toBoardResponse(board *models.Board) dto.BoardResponse {
 	response := dto.BoardResponse{
-		ID:          board.ID,
-		Name:        board.Name,
-		Description: board.Description,
-		Color:       board.Color,
-		Position:    board.Position,
-		CreatedAt:   board.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		ID:           board.ID,
+		Name:         board.Name,
+		Description:  board.Description,
+		Color:        board.Color,
+		Position:     board.Position,
+		WIPLimitMode: board.WIPLimitMode,
+		CreatedAt:    board.CreatedAt.Format("2006-01-02T15:04:05Z"),
 	}
 
 	if board.Columns != nil {
@@ -174,23 +234,57 @@ func toBoardResponse(board *models.Board) dto.BoardResponse {
 				Title:    col.Title,
 				Position: col.Position,
 				BoardID:  col.BoardID,
+				WIPLimit: col.WIPLimit,
 			}
 
 			if col.Cards != nil {
 				response.Columns[i].Cards = make([]dto.CardResponse, len(col.Cards))
 				for j, card := range col.Cards {
-					response.Columns[i].Cards[j] = dto.CardResponse{
-						ID:          card.ID,
-						Title:       card.Title,
-						Description: card.Description,
-						Position:    card.Position,
-						Priority:    card.Priority,
-						ColumnID:    card.ColumnID,
-					}
+					response.Columns[i].Cards[j] = toCardResponse(&card)
 				}
 			}
 		}
 	}
 
+	if board.Swimlanes != nil {
+		response.Swimlanes = make([]dto.SwimlaneResponse, len(board.Swimlanes))
+		for i, swimlane := range board.Swimlanes {
+			response.Swimlanes[i] = toSwimlaneResponse(&swimlane)
+			response.Swimlanes[i].Cells = swimlaneCells(board.Columns, swimlane.ID)
+		}
+	}
+
 	return response
 }
+
+// swimlaneCells lays out the cards of a swimlane by column, one cell per column
+func swimlaneCells(columns []models.Column, swimlaneID uint) []dto.SwimlaneCellResponse {
+	cells := make([]dto.SwimlaneCellResponse, len(columns))
+	for i, col := range columns {
+		cells[i] = dto.SwimlaneCellResponse{ColumnID: col.ID, CardIDs: []uint{}}
+		for _, card := range col.Cards {
+			if card.SwimlaneID != nil && *card.SwimlaneID == swimlaneID {
+				cells[i].CardIDs = append(cells[i].CardIDs, card.ID)
+			}
+		}
+	}
+	return cells
+}
+
+// parseIDList parses a comma-separated list of IDs such as "1,2,3"
+func parseIDList(value string) ([]uint, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(value, ",")
+	ids := make([]uint, 0, len(parts))
+	for _, part := range parts {
+		id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 32)
+		if err != nil || id == 0 {
+			return nil, errors.New("invalid ID list")
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/icl00ud/goban/internal/dto"
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/services"
+	"github.com/icl00ud/goban/internal/utils"
+)
+
+type MemberHandler struct {
+	memberService *services.MemberService
+}
+
+func NewMemberHandler(memberService *services.MemberService) *MemberHandler {
+	return &MemberHandler{memberService: memberService}
+}
+
+// List returns all members of a board
+func (h *MemberHandler) List(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	boardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board ID")
+	}
+
+	members, err := h.memberService.List(uint(boardID), userID)
+	if err != nil {
+		if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
+			return utils.Forbidden(c, err.Error())
+		}
+		if errors.Is(err, services.ErrBoardNotFound) {
+			return utils.NotFound(c, err.Error())
+		}
+		return utils.InternalError(c, "Failed to fetch members")
+	}
+
+	response := make([]dto.BoardMemberResponse, len(members))
+	for i, member := range members {
+		response[i] = toBoardMemberResponse(&member)
+	}
+
+	return utils.Success(c, response)
+}
+
+// Add invites a user to a board
+func (h *MemberHandler) Add(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	boardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board ID")
+	}
+
+	var req dto.AddMemberRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+
+	req.Email = strings.TrimSpace(req.Email)
+	if req.Email == "" {
+		return utils.BadRequest(c, "Email is required")
+	}
+
+	member, err := h.memberService.Add(uint(boardID), userID, req.Email, req.Role)
+	if err != nil {
+		if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
+			return utils.Forbidden(c, err.Error())
+		}
+		if errors.Is(err, services.ErrUserNotFound) {
+			return utils.NotFound(c, err.Error())
+		}
+		if errors.Is(err, services.ErrInvalidRole) || errors.Is(err, services.ErrMemberExists) {
+			return utils.BadRequest(c, err.Error())
+		}
+		return utils.InternalError(c, "Failed to add member")
+	}
+
+	return utils.Created(c, toBoardMemberResponse(member))
+}
+
+// UpdateRole changes the role of a board member
+func (h *MemberHandler) UpdateRole(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	boardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board ID")
+	}
+	memberUserID, err := c.ParamsInt("userId")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid user ID")
+	}
+
+	var req dto.UpdateMemberRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+
+	member, err := h.memberService.UpdateRole(uint(boardID), userID, uint(memberUserID), req.Role)
+	if err != nil {
+		if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
+			return utils.Forbidden(c, err.Error())
+		}
+		if errors.Is(err, services.ErrMemberNotFound) {
+			return utils.NotFound(c, err.Error())
+		}
+		if errors.Is(err, services.ErrInvalidRole) || errors.Is(err, services.ErrBoardCreatorMember) {
+			return utils.BadRequest(c, err.Error())
+		}
+		return utils.InternalError(c, "Failed to update member")
+	}
+
+	return utils.Success(c, toBoardMemberResponse(member))
+}
+
+// Remove removes a member from a board
+func (h *MemberHandler) Remove(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	boardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board ID")
+	}
+	memberUserID, err := c.ParamsInt("userId")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid user ID")
+	}
+
+	err = h.memberService.Remove(uint(boardID), userID, uint(memberUserID))
+	if err != nil {
+		if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
+			return utils.Forbidden(c, err.Error())
+		}
+		if errors.Is(err, services.ErrMemberNotFound) {
+			return utils.NotFound(c, err.Error())
+		}
+		if errors.Is(err, services.ErrBoardCreatorMember) {
+			return utils.BadRequest(c, err.Error())
+		}
+		return utils.InternalError(c, "Failed to remove member")
+	}
+
+	return utils.SuccessWithMessage(c, "Member removed successfully")
+}
+
+// toBoardMemberResponse converts a BoardMember model to BoardMemberResponse DTO
+func toBoardMemberResponse(member *models.BoardMember) dto.BoardMemberResponse {
+	return dto.BoardMemberResponse{
+		UserID: member.UserID,
+		Email:  member.User.Email,
+		Name:   member.User.Name,
+		Role:   member.Role,
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/icl00ud/goban/internal/dto"
+	"github.com/icl00ud/goban/internal/models"
 	"github.com/icl00ud/goban/internal/services"
 	"github.com/icl00ud/goban/internal/utils"
 )
@@ -34,25 +35,27 @@ func (h *CardHandler) Create(c *fiber.Ctx) error {
 		return utils.BadRequest(c, "Card title is required")
 	}
 
-	card, err := h.cardService.Create(uint(columnID), userID, req.Title, req.Description, req.Priority)
+	card, err := h.cardService.Create(uint(columnID), userID, &req)
 	if err != nil {
-		if errors.Is(err, services.ErrNotBoardOwner) {
+		if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
 			return utils.Forbidden(c, err.Error())
 		}
 		if errors.Is(err, services.ErrColumnNotFound) {
 			return utils.NotFound(c, err.Error())
 		}
+		if errors.Is(err, services.ErrInvalidDateRange) {
+			return utils.BadRequest(c, err.Error())
+		}
+		if errors.Is(err, services.ErrSwimlaneNotFound) || errors.Is(err, services.ErrSwimlaneWrongBoard) {
+			return utils.BadRequest(c, err.Error())
+		}
+		if errors.Is(err, services.ErrWIPLimitExceeded) {
+			return utils.Error(c, fiber.StatusConflict, err.Error())
+		}
 		return utils.InternalError(c, "Failed to create card")
 	}
 
-	return utils.Created(c, dto.CardResponse{
-		ID:          card.ID,
-		Title:       card.Title,
-		Description: card.Description,
-		Position:    card.Position,
-		Priority:    card.Priority,
-		ColumnID:    card.ColumnID,
-	})
+	return utils.Created(c, toCardResponse(card))
 }
 
 // Get retrieves a card by ID
@@ -65,7 +68,7 @@ func (h *CardHandler) Get(c *fiber.Ctx) error {
 
 	card, err := h.cardService.GetByID(uint(cardID), userID)
 	if err != nil {
-		if errors.Is(err, services.ErrNotBoardOwner) {
+		if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
 			return utils.Forbidden(c, err.Error())
 		}
 		if errors.Is(err, services.ErrCardNotFound) {
@@ -74,14 +77,7 @@ func (h *CardHandler) Get(c *fiber.Ctx) error {
 		return utils.InternalError(c, "Failed to fetch card")
 	}
 
-	return utils.Success(c, dto.CardResponse{
-		ID:          card.ID,
-		Title:       card.Title,
-		Description: card.Description,
-		Position:    card.Position,
-		Priority:    card.Priority,
-		ColumnID:    card.ColumnID,
-	})
+	return utils.Success(c, toCardResponse(card))
 }
 
 // Update updates a card
@@ -97,25 +93,21 @@ func (h *CardHandler) Update(c *fiber.Ctx) error {
 		return utils.BadRequest(c, "Invalid request body")
 	}
 
-	card, err := h.cardService.Update(uint(cardID), userID, req.Title, req.Description, req.Priority)
+	card, err := h.cardService.Update(uint(cardID), userID, &req)
 	if err != nil {
-		if errors.Is(err, services.ErrNotBoardOwner) {
+		if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
 			return utils.Forbidden(c, err.Error())
 		}
 		if errors.Is(err, services.ErrCardNotFound) {
 			return utils.NotFound(c, err.Error())
 		}
+		if errors.Is(err, services.ErrInvalidDateRange) {
+			return utils.BadRequest(c, err.Error())
+		}
 		return utils.InternalError(c, "Failed to update card")
 	}
 
-	return utils.Success(c, dto.CardResponse{
-		ID:          card.ID,
-		Title:       card.Title,
-		Description: card.Description,
-		Position:    card.Position,
-		Priority:    card.Priority,
-		ColumnID:    card.ColumnID,
-	})
+	return utils.Success(c, toCardResponse(card))
 }
 
 // Delete deletes a card
@@ -128,7 +120,7 @@ func (h *CardHandler) Delete(c *fiber.Ctx) error {
 
 	err = h.cardService.Delete(uint(cardID), userID)
 	if err != nil {
-		if errors.Is(err, services.ErrNotBoardOwner) {
+		if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
 			return utils.Forbidden(c, err.Error())
 		}
 		if errors.Is(err, services.ErrCardNotFound) {
@@ -153,29 +145,28 @@ func (h *CardHandler) Move(c *fiber.Ctx) error {
 		return utils.BadRequest(c, "Invalid request body")
 	}
 
-	if req.TargetColumnID == 0 {
-		return utils.BadRequest(c, "Target column ID is required")
+	if req.TargetColumnID == 0 && !req.SwimlaneID.Set {
+		return utils.BadRequest(c, "Target column ID or swimlane ID is required")
 	}
 
-	card, err := h.cardService.Move(uint(cardID), userID, req.TargetColumnID, req.Position)
+	card, err := h.cardService.Move(uint(cardID), userID, req.TargetColumnID, req.SwimlaneID, req.Position)
 	if err != nil {
-		if errors.Is(err, services.ErrNotBoardOwner) {
+		if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
 			return utils.Forbidden(c, err.Error())
 		}
 		if errors.Is(err, services.ErrCardNotFound) || errors.Is(err, services.ErrColumnNotFound) {
 			return utils.NotFound(c, err.Error())
 		}
+		if errors.Is(err, services.ErrSwimlaneNotFound) || errors.Is(err, services.ErrSwimlaneWrongBoard) {
+			return utils.BadRequest(c, err.Error())
+		}
+		if errors.Is(err, services.ErrWIPLimitExceeded) {
+			return utils.Error(c, fiber.StatusConflict, err.Error())
+		}
 		return utils.InternalError(c, "Failed to move card")
 	}
 
-	return utils.Success(c, dto.CardResponse{
-		ID:          card.ID,
-		Title:       card.Title,
-		Description: card.Description,
-		Position:    card.Position,
-		Priority:    card.Priority,
-		ColumnID:    card.ColumnID,
-	})
+	return utils.Success(c, toCardResponse(card))
 }
 
 // Reorder reorders cards within a column
@@ -193,7 +184,7 @@ func (h *CardHandler) Reorder(c *fiber.Ctx) error {
 
 	err := h.cardService.Reorder(req.ColumnID, userID, req.CardIDs)
 	if err != nil {
-		if errors.Is(err, services.ErrNotBoardOwner) {
+		if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
 			return utils.Forbidden(c, err.Error())
 		}
 		if errors.Is(err, services.ErrColumnNotFound) {
@@ -204,3 +195,152 @@ func (h *CardHandler) Reorder(c *fiber.Ctx) error {
 
 	return utils.SuccessWithMessage(c, "Cards reordered successfully")
 }
+
+// Assign assigns a user to a card
+func (h *CardHandler) Assign(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	cardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card ID")
+	}
+
+	var req dto.AssignCardRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+
+	if req.UserID == 0 {
+		return utils.BadRequest(c, "User ID is required")
+	}
+
+	card, err := h.cardService.Assign(uint(cardID), userID, req.UserID)
+	if err != nil {
+		return assigneeError(c, err, "Failed to assign card")
+	}
+
+	return utils.Success(c, toCardResponse(card))
+}
+
+// Unassign removes a user from a card
+func (h *CardHandler) Unassign(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	cardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card ID")
+	}
+	assigneeID, err := c.ParamsInt("userId")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid user ID")
+	}
+
+	card, err := h.cardService.Unassign(uint(cardID), userID, uint(assigneeID))
+	if err != nil {
+		return assigneeError(c, err, "Failed to unassign card")
+	}
+
+	return utils.Success(c, toCardResponse(card))
+}
+
+// ListAssigned returns the cards assigned to the authenticated user grouped by board and column
+func (h *CardHandler) ListAssigned(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	boards, err := h.cardService.GetAssignedToUser(userID)
+	if err != nil {
+		return utils.InternalError(c, "Failed to fetch assigned cards")
+	}
+
+	response := make([]dto.BoardResponse, len(boards))
+	for i, board := range boards {
+		response[i] = toBoardResponse(&board)
+	}
+
+	return utils.Success(c, response)
+}
+
+// ListOverdue returns cards past their due date across all accessible boards
+func (h *CardHandler) ListOverdue(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	boards, err := h.cardService.GetOverdue(userID)
+	if err != nil {
+		return utils.InternalError(c, "Failed to fetch overdue cards")
+	}
+
+	response := make([]dto.BoardResponse, len(boards))
+	for i, board := range boards {
+		response[i] = toBoardResponse(&board)
+	}
+
+	return utils.Success(c, response)
+}
+
+// ListDueSoon returns cards due within the next ?days=N days (default 7)
+// across all accessible boards
+func (h *CardHandler) ListDueSoon(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	days := c.QueryInt("days", 7)
+	if days < 1 {
+		return utils.BadRequest(c, "days must be a positive number")
+	}
+
+	boards, err := h.cardService.GetDueWithin(userID, days)
+	if err != nil {
+		return utils.InternalError(c, "Failed to fetch upcoming cards")
+	}
+
+	response := make([]dto.BoardResponse, len(boards))
+	for i, board := range boards {
+		response[i] = toBoardResponse(&board)
+	}
+
+	return utils.Success(c, response)
+}
+
+// assigneeError maps assignee service errors to responses
+func assigneeError(c *fiber.Ctx, err error, fallback string) error {
+	if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
+		return utils.Forbidden(c, err.Error())
+	}
+	if errors.Is(err, services.ErrCardNotFound) || errors.Is(err, services.ErrColumnNotFound) || errors.Is(err, services.ErrUserNotFound) {
+		return utils.NotFound(c, err.Error())
+	}
+	if errors.Is(err, services.ErrAssigneeNoAccess) {
+		return utils.BadRequest(c, err.Error())
+	}
+	return utils.InternalError(c, fallback)
+}
+
+// toCardResponse converts a Card model to CardResponse DTO
+func toCardResponse(card *models.Card) dto.CardResponse {
+	response := dto.CardResponse{
+		ID:           card.ID,
+		Title:        card.Title,
+		Description:  card.Description,
+		Position:     card.Position,
+		Priority:     card.Priority,
+		ColumnID:     card.ColumnID,
+		SwimlaneID:   card.SwimlaneID,
+		StartAt:      card.StartAt,
+		DueAt:        card.DueAt,
+		CommentCount: card.CommentCount,
+		ChecklistProgress: dto.ChecklistProgress{
+			Done:  card.ChecklistDone,
+			Total: card.ChecklistTotal,
+		},
+		Assignees:        make([]dto.UserResponse, len(card.Assignees)),
+		Labels:           make([]dto.LabelResponse, len(card.Labels)),
+		WIPLimitExceeded: card.WIPExceeded,
+	}
+
+	for i, user := range card.Assignees {
+		response.Assignees[i] = toUserResponse(&user)
+	}
+
+	for i, label := range card.Labels {
+		response.Labels[i] = toLabelResponse(&label)
+	}
+
+	return response
+}
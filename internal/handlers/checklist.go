@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/icl00ud/goban/internal/dto"
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/services"
+	"github.com/icl00ud/goban/internal/utils"
+)
+
+type ChecklistHandler struct {
+	checklistService *services.ChecklistService
+}
+
+func NewChecklistHandler(checklistService *services.ChecklistService) *ChecklistHandler {
+	return &ChecklistHandler{checklistService: checklistService}
+}
+
+// List returns all checklists of a card
+func (h *ChecklistHandler) List(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	cardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card ID")
+	}
+
+	checklists, err := h.checklistService.List(uint(cardID), userID)
+	if err != nil {
+		return checklistError(c, err, "Failed to fetch checklists")
+	}
+
+	return utils.Success(c, toChecklistResponses(checklists))
+}
+
+// Create adds a checklist to a card
+func (h *ChecklistHandler) Create(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	cardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card ID")
+	}
+
+	var req dto.CreateChecklistRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+
+	req.Title = strings.TrimSpace(req.Title)
+	if req.Title == "" {
+		return utils.BadRequest(c, "Checklist title is required")
+	}
+
+	checklist, err := h.checklistService.Create(uint(cardID), userID, req.Title)
+	if err != nil {
+		return checklistError(c, err, "Failed to create checklist")
+	}
+
+	return utils.Created(c, toChecklistResponse(checklist))
+}
+
+// Update renames a checklist
+func (h *ChecklistHandler) Update(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	cardID, checklistID, err := checklistParams(c)
+	if err != nil {
+		return utils.BadRequest(c, err.Error())
+	}
+
+	var req dto.UpdateChecklistRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+
+	checklist, err := h.checklistService.Update(cardID, checklistID, userID, strings.TrimSpace(req.Title))
+	if err != nil {
+		return checklistError(c, err, "Failed to update checklist")
+	}
+
+	return utils.Success(c, toChecklistResponse(checklist))
+}
+
+// Delete deletes a checklist
+func (h *ChecklistHandler) Delete(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	cardID, checklistID, err := checklistParams(c)
+	if err != nil {
+		return utils.BadRequest(c, err.Error())
+	}
+
+	if err := h.checklistService.Delete(cardID, checklistID, userID); err != nil {
+		return checklistError(c, err, "Failed to delete checklist")
+	}
+
+	return utils.SuccessWithMessage(c, "Checklist deleted successfully")
+}
+
+// Reorder reorders the checklists of a card
+func (h *ChecklistHandler) Reorder(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	cardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card ID")
+	}
+
+	var req dto.ReorderChecklistsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+
+	if len(req.ChecklistIDs) == 0 {
+		return utils.BadRequest(c, "checklist_ids is required")
+	}
+
+	checklists, err := h.checklistService.Reorder(uint(cardID), userID, req.ChecklistIDs)
+	if err != nil {
+		return checklistError(c, err, "Failed to reorder checklists")
+	}
+
+	return utils.Success(c, toChecklistResponses(checklists))
+}
+
+// AddItem adds an item to a checklist
+func (h *ChecklistHandler) AddItem(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	cardID, checklistID, err := checklistParams(c)
+	if err != nil {
+		return utils.BadRequest(c, err.Error())
+	}
+
+	var req dto.CreateChecklistItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+
+	req.Text = strings.TrimSpace(req.Text)
+	if req.Text == "" {
+		return utils.BadRequest(c, "Item text is required")
+	}
+
+	checklist, err := h.checklistService.AddItem(cardID, checklistID, userID, req.Text)
+	if err != nil {
+		return checklistError(c, err, "Failed to add checklist item")
+	}
+
+	return utils.Created(c, toChecklistResponse(checklist))
+}
+
+// UpdateItem edits or checks/unchecks a checklist item
+func (h *ChecklistHandler) UpdateItem(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	cardID, checklistID, err := checklistParams(c)
+	if err != nil {
+		return utils.BadRequest(c, err.Error())
+	}
+	itemID, err := c.ParamsInt("itemId")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid item ID")
+	}
+
+	var req dto.UpdateChecklistItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+	req.Text = strings.TrimSpace(req.Text)
+
+	checklist, err := h.checklistService.UpdateItem(cardID, checklistID, uint(itemID), userID, &req)
+	if err != nil {
+		return checklistError(c, err, "Failed to update checklist item")
+	}
+
+	return utils.Success(c, toChecklistResponse(checklist))
+}
+
+// DeleteItem deletes a checklist item
+func (h *ChecklistHandler) DeleteItem(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	cardID, checklistID, err := checklistParams(c)
+	if err != nil {
+		return utils.BadRequest(c, err.Error())
+	}
+	itemID, err := c.ParamsInt("itemId")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid item ID")
+	}
+
+	checklist, err := h.checklistService.DeleteItem(cardID, checklistID, uint(itemID), userID)
+	if err != nil {
+		return checklistError(c, err, "Failed to delete checklist item")
+	}
+
+	return utils.Success(c, toChecklistResponse(checklist))
+}
+
+// ReorderItems reorders the items of a checklist
+func (h *ChecklistHandler) ReorderItems(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	cardID, checklistID, err := checklistParams(c)
+	if err != nil {
+		return utils.BadRequest(c, err.Error())
+	}
+
+	var req dto.ReorderChecklistItemsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+
+	if len(req.ItemIDs) == 0 {
+		return utils.BadRequest(c, "item_ids is required")
+	}
+
+	checklist, err := h.checklistService.ReorderItems(cardID, checklistID, userID, req.ItemIDs)
+	if err != nil {
+		return checklistError(c, err, "Failed to reorder checklist items")
+	}
+
+	return utils.Success(c, toChecklistResponse(checklist))
+}
+
+// checklistParams parses the card and checklist IDs from the route
+func checklistParams(c *fiber.Ctx) (uint, uint, error) {
+	cardID, err := c.ParamsInt("id")
+	if err != nil {
+		return 0, 0, errors.New("Invalid card ID")
+	}
+	checklistID, err := c.ParamsInt("checklistId")
+	if err != nil {
+		return 0, 0, errors.New("Invalid checklist ID")
+	}
+	return uint(cardID), uint(checklistID), nil
+}
+
+// checklistError maps checklist service errors to responses
+func checklistError(c *fiber.Ctx, err error, fallback string) error {
+	if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
+		return utils.Forbidden(c, err.Error())
+	}
+	if errors.Is(err, services.ErrCardNotFound) || errors.Is(err, services.ErrChecklistNotFound) || errors.Is(err, services.ErrChecklistItemNotFound) {
+		return utils.NotFound(c, err.Error())
+	}
+	return utils.InternalError(c, fallback)
+}
+
+// toChecklistResponses converts Checklist models to ChecklistResponse DTOs
+func toChecklistResponses(checklists []models.Checklist) []dto.ChecklistResponse {
+	response := make([]dto.ChecklistResponse, len(checklists))
+	for i, checklist := range checklists {
+		response[i] = toChecklistResponse(&checklist)
+	}
+	return response
+}
+
+// toChecklistResponse converts a Checklist model to ChecklistResponse DTO
+func toChecklistResponse(checklist *models.Checklist) dto.ChecklistResponse {
+	response := dto.ChecklistResponse{
+		ID:       checklist.ID,
+		Title:    checklist.Title,
+		Position: checklist.Position,
+		CardID:   checklist.CardID,
+		Items:    make([]dto.ChecklistItemResponse, len(checklist.Items)),
+	}
+
+	for i, item := range checklist.Items {
+		response.Items[i] = dto.ChecklistItemResponse{
+			ID:       item.ID,
+			Text:     item.Text,
+			Done:     item.Done,
+			Position: item.Position,
+		}
+	}
+
+	return response
+}
@@ -36,7 +36,7 @@ func (h *ColumnHandler) Create(c *fiber.Ctx) error {
 
 	column, err := h.columnService.Create(uint(boardID), userID, req.Title)
 	if err != nil {
-		if errors.Is(err, services.ErrNotBoardOwner) {
+		if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
 			return utils.Forbidden(c, err.Error())
 		}
 		return utils.InternalError(c, "Failed to create column")
@@ -47,6 +47,7 @@ func (h *ColumnHandler) Create(c *fiber.Ctx) error {
 		Title:    column.Title,
 		Position: column.Position,
 		BoardID:  column.BoardID,
+		WIPLimit: column.WIPLimit,
 	})
 }
 
@@ -63,14 +64,17 @@ func (h *ColumnHandler) Update(c *fiber.Ctx) error {
 		return utils.BadRequest(c, "Invalid request body")
 	}
 
-	column, err := h.columnService.Update(uint(columnID), userID, req.Title)
+	column, err := h.columnService.Update(uint(columnID), userID, req.Title, req.WIPLimit)
 	if err != nil {
-		if errors.Is(err, services.ErrNotBoardOwner) {
+		if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
 			return utils.Forbidden(c, err.Error())
 		}
 		if errors.Is(err, services.ErrColumnNotFound) {
 			return utils.NotFound(c, err.Error())
 		}
+		if errors.Is(err, services.ErrInvalidWIPLimit) {
+			return utils.BadRequest(c, err.Error())
+		}
 		return utils.InternalError(c, "Failed to update column")
 	}
 
@@ -79,6 +83,7 @@ func (h *ColumnHandler) Update(c *fiber.Ctx) error {
 		Title:    column.Title,
 		Position: column.Position,
 		BoardID:  column.BoardID,
+		WIPLimit: column.WIPLimit,
 	})
 }
 
@@ -92,7 +97,7 @@ func (h *ColumnHandler) Delete(c *fiber.Ctx) error {
 
 	err = h.columnService.Delete(uint(columnID), userID)
 	if err != nil {
-		if errors.Is(err, services.ErrNotBoardOwner) {
+		if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
 			return utils.Forbidden(c, err.Error())
 		}
 		if errors.Is(err, services.ErrColumnNotFound) {
@@ -119,7 +124,7 @@ func (h *ColumnHandler) Reorder(c *fiber.Ctx) error {
 
 	err := h.columnService.Reorder(req.BoardID, userID, req.ColumnIDs)
 	if err != nil {
-		if errors.Is(err, services.ErrNotBoardOwner) {
+		if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
 			return utils.Forbidden(c, err.Error())
 		}
 		return utils.InternalError(c, "Failed to reorder columns")
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/icl00ud/goban/internal/dto"
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/services"
+	"github.com/icl00ud/goban/internal/utils"
+)
+
+type CommentHandler struct {
+	commentService *services.CommentService
+}
+
+func NewCommentHandler(commentService *services.CommentService) *CommentHandler {
+	return &CommentHandler{commentService: commentService}
+}
+
+// List returns all comments of a card
+func (h *CommentHandler) List(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	cardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card ID")
+	}
+
+	comments, err := h.commentService.List(uint(cardID), userID)
+	if err != nil {
+		return commentError(c, err, "Failed to fetch comments")
+	}
+
+	response := make([]dto.CommentResponse, len(comments))
+	for i, comment := range comments {
+		response[i] = toCommentResponse(&comment)
+	}
+
+	return utils.Success(c, response)
+}
+
+// Create adds a comment to a card
+func (h *CommentHandler) Create(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	cardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card ID")
+	}
+
+	var req dto.CommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+
+	comment, err := h.commentService.Create(uint(cardID), userID, req.Body)
+	if err != nil {
+		return commentError(c, err, "Failed to create comment")
+	}
+
+	return utils.Created(c, toCommentResponse(comment))
+}
+
+// Update edits a comment
+func (h *CommentHandler) Update(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	commentID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid comment ID")
+	}
+
+	var req dto.CommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+
+	comment, err := h.commentService.Update(uint(commentID), userID, req.Body)
+	if err != nil {
+		return commentError(c, err, "Failed to update comment")
+	}
+
+	return utils.Success(c, toCommentResponse(comment))
+}
+
+// Delete deletes a comment
+func (h *CommentHandler) Delete(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	commentID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid comment ID")
+	}
+
+	if err := h.commentService.Delete(uint(commentID), userID); err != nil {
+		return commentError(c, err, "Failed to delete comment")
+	}
+
+	return utils.SuccessWithMessage(c, "Comment deleted successfully")
+}
+
+// History returns the edit and delete history of a comment
+func (h *CommentHandler) History(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	commentID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid comment ID")
+	}
+
+	comment, revisions, err := h.commentService.History(uint(commentID), userID)
+	if err != nil {
+		return commentError(c, err, "Failed to fetch comment history")
+	}
+
+	response := dto.CommentHistoryResponse{
+		Comment:   toCommentResponse(comment),
+		Deleted:   comment.DeletedAt.Valid,
+		Revisions: make([]dto.CommentRevisionResponse, len(revisions)),
+	}
+	for i, revision := range revisions {
+		response.Revisions[i] = dto.CommentRevisionResponse{
+			Action:    revision.Action,
+			Body:      revision.Body,
+			User:      toUserResponse(&revision.User),
+			CreatedAt: revision.CreatedAt,
+		}
+	}
+
+	return utils.Success(c, response)
+}
+
+// commentError maps comment service errors to responses
+func commentError(c *fiber.Ctx, err error, fallback string) error {
+	if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) || errors.Is(err, services.ErrNotCommentAuthor) {
+		return utils.Forbidden(c, err.Error())
+	}
+	if errors.Is(err, services.ErrCommentNotFound) || errors.Is(err, services.ErrCardNotFound) {
+		return utils.NotFound(c, err.Error())
+	}
+	if errors.Is(err, services.ErrCommentEmpty) || errors.Is(err, services.ErrCommentTooLong) {
+		return utils.BadRequest(c, err.Error())
+	}
+	return utils.InternalError(c, fallback)
+}
+
+// toCommentResponse converts a Comment model to CommentResponse DTO
+func toCommentResponse(comment *models.Comment) dto.CommentResponse {
+	return dto.CommentResponse{
+		ID:        comment.ID,
+		CardID:    comment.CardID,
+		Body:      comment.Body,
+		Author:    toUserResponse(&comment.User),
+		EditedAt:  comment.EditedAt,
+		CreatedAt: comment.CreatedAt,
+	}
+}
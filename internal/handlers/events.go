@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/icl00ud/goban/internal/events"
+	"github.com/icl00ud/goban/internal/services"
+	"github.com/icl00ud/goban/internal/utils"
+)
+
+// heartbeatInterval keeps idle connections open through proxies and is also
+// when the subscriber's credentials and board access are re-checked
+const heartbeatInterval = 25 * time.Second
+
+type EventHandler struct {
+	boardService   *services.BoardService
+	sessionService *services.SessionService
+	tokenService   *services.APITokenService
+	broker         *events.Broker
+}
+
+func NewEventHandler(boardService *services.BoardService, sessionService *services.SessionService, tokenService *services.APITokenService, broker *events.Broker) *EventHandler {
+	return &EventHandler{
+		boardService:   boardService,
+		sessionService: sessionService,
+		tokenService:   tokenService,
+		broker:         broker,
+	}
+}
+
+// Stream sends board events to the client as Server-Sent Events
+func (h *EventHandler) Stream(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	boardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board ID")
+	}
+
+	if err := h.boardService.CheckAccess(uint(boardID), userID); err != nil {
+		if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
+			return utils.Forbidden(c, err.Error())
+		}
+		return utils.InternalError(c, "Failed to subscribe to board events")
+	}
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	// The context is not usable once streaming starts, so capture how the
+	// request was authenticated now
+	authenticated := h.credentialsCheck(c)
+
+	subscription, unsubscribe := h.broker.Subscribe(uint(boardID))
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+
+		fmt.Fprint(w, ": connected\n\n")
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case event := <-subscription:
+				payload, err := json.Marshal(event)
+				if err != nil {
+					continue
+				}
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, payload)
+			case <-ticker.C:
+				// Stop streaming once the session or API token is revoked or
+				// the user loses access to the board
+				if !authenticated() || h.boardService.CheckAccess(uint(boardID), userID) != nil {
+					return
+				}
+				fmt.Fprint(w, ": ping\n\n")
+			}
+
+			// A flush error means the client went away
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+// credentialsCheck returns a check that the session or API token the request
+// was authenticated with is still valid
+func (h *EventHandler) credentialsCheck(c *fiber.Ctx) func() bool {
+	if tokenID, ok := c.Locals("apiTokenID").(uint); ok {
+		return func() bool { return h.tokenService.StillValid(tokenID) }
+	}
+	sessionID, _ := c.Locals("sessionID").(uint)
+	return func() bool { return h.sessionService.StillActive(sessionID) }
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/icl00ud/goban/internal/dto"
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/services"
+	"github.com/icl00ud/goban/internal/utils"
+)
+
+type ExportHandler struct {
+	exportService *services.ExportService
+}
+
+func NewExportHandler(exportService *services.ExportService) *ExportHandler {
+	return &ExportHandler{exportService: exportService}
+}
+
+// Export downloads a board as a JSON export document
+func (h *ExportHandler) Export(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	boardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board ID")
+	}
+
+	doc, err := h.exportService.Export(uint(boardID), userID)
+	if err != nil {
+		return exportError(c, err, "Failed to export board")
+	}
+
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="board-%d.json"`, boardID))
+	return c.JSON(doc)
+}
+
+// ExportCSV streams a board as CSV with one row per card
+func (h *ExportHandler) ExportCSV(c *fiber.Ctx) error {
+	return h.stream(c, "csv", "text/csv; charset=utf-8", h.exportService.WriteCSV)
+}
+
+// ExportMarkdown streams a board as Markdown with a heading per column and a
+// task list item per card
+func (h *ExportHandler) ExportMarkdown(c *fiber.Ctx) error {
+	return h.stream(c, "md", "text/markdown; charset=utf-8", h.exportService.WriteMarkdown)
+}
+
+// stream checks access to a board and then writes it to the response as it
+// is read, so large boards are never buffered whole
+func (h *ExportHandler) stream(c *fiber.Ctx, extension, contentType string, write func(io.Writer, *models.Board) error) error {
+	userID := c.Locals("userID").(uint)
+	boardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board ID")
+	}
+
+	board, err := h.exportService.StreamableBoard(uint(boardID), userID)
+	if err != nil {
+		return exportError(c, err, "Failed to export board")
+	}
+
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="board-%d.%s"`, boardID, extension))
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		// Headers are already sent, so a failure can only cut the body short
+		if err := write(w, board); err != nil {
+			log.Printf("Failed to stream %s export of board %d: %v", extension, boardID, err)
+			return
+		}
+		w.Flush()
+	})
+
+	return nil
+}
+
+// Import creates a new board from a JSON export document
+func (h *ExportHandler) Import(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	var doc dto.BoardExport
+	if err := c.BodyParser(&doc); err != nil {
+		return utils.BadRequest(c, "Invalid export document")
+	}
+
+	board, err := h.exportService.Import(userID, &doc)
+	if err != nil {
+		return exportError(c, err, "Failed to import board")
+	}
+
+	return utils.Created(c, toBoardResponse(board))
+}
+
+// ImportTrello creates a new board from a Trello board JSON export. With
+// ?dry_run=true it only reports what would be created and lost, and with
+// ?include_archived=true archived lists and cards are imported too.
+func (h *ExportHandler) ImportTrello(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	var trello dto.TrelloBoard
+	if err := c.BodyParser(&trello); err != nil {
+		return utils.BadRequest(c, "Invalid Trello export")
+	}
+
+	opts := services.TrelloImportOptions{
+		DryRun:          c.QueryBool("dry_run"),
+		IncludeArchived: c.QueryBool("include_archived"),
+	}
+
+	board, report, err := h.exportService.ImportTrello(userID, &trello, opts)
+	if err != nil {
+		return exportError(c, err, "Failed to import Trello board")
+	}
+
+	response := dto.TrelloImportResponse{
+		DryRun: opts.DryRun,
+		Created: dto.TrelloImportSummary{
+			Columns:        report.Columns,
+			Cards:          report.Cards,
+			Labels:         report.Labels,
+			Checklists:     report.Checklists,
+			ChecklistItems: report.ChecklistItems,
+			Comments:       report.Comments,
+		},
+		Lost: report.Lost,
+	}
+	if response.Lost == nil {
+		response.Lost = []string{}
+	}
+
+	if board == nil {
+		return utils.Success(c, response)
+	}
+
+	boardResponse := toBoardResponse(board)
+	response.Board = &boardResponse
+	return utils.Created(c, response)
+}
+
+// exportError maps export service errors to responses
+func exportError(c *fiber.Ctx, err error, fallback string) error {
+	if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
+		return utils.Forbidden(c, err.Error())
+	}
+	if errors.Is(err, services.ErrBoardNotFound) {
+		return utils.NotFound(c, err.Error())
+	}
+	if errors.Is(err, services.ErrUnsupportedExportVersion) || errors.Is(err, services.ErrInvalidImport) {
+		return utils.BadRequest(c, err.Error())
+	}
+	return utils.InternalError(c, fallback)
+}
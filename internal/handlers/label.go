@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/icl00ud/goban/internal/dto"
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/services"
+	"github.com/icl00ud/goban/internal/utils"
+)
+
+type LabelHandler struct {
+	labelService *services.LabelService
+}
+
+func NewLabelHandler(labelService *services.LabelService) *LabelHandler {
+	return &LabelHandler{labelService: labelService}
+}
+
+// List returns all labels of a board
+func (h *LabelHandler) List(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	boardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board ID")
+	}
+
+	labels, err := h.labelService.List(uint(boardID), userID)
+	if err != nil {
+		return labelError(c, err, "Failed to fetch labels")
+	}
+
+	response := make([]dto.LabelResponse, len(labels))
+	for i, label := range labels {
+		response[i] = toLabelResponse(&label)
+	}
+
+	return utils.Success(c, response)
+}
+
+// Create creates a new label on a board
+func (h *LabelHandler) Create(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	boardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board ID")
+	}
+
+	var req dto.CreateLabelRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+
+	label, err := h.labelService.Create(uint(boardID), userID, strings.TrimSpace(req.Name), req.Color)
+	if err != nil {
+		return labelError(c, err, "Failed to create label")
+	}
+
+	return utils.Created(c, toLabelResponse(label))
+}
+
+// Update updates a board label
+func (h *LabelHandler) Update(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	boardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board ID")
+	}
+	labelID, err := c.ParamsInt("labelId")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid label ID")
+	}
+
+	var req dto.UpdateLabelRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+
+	label, err := h.labelService.Update(uint(boardID), uint(labelID), userID, strings.TrimSpace(req.Name), req.Color)
+	if err != nil {
+		return labelError(c, err, "Failed to update label")
+	}
+
+	return utils.Success(c, toLabelResponse(label))
+}
+
+// Delete deletes a board label
+func (h *LabelHandler) Delete(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	boardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board ID")
+	}
+	labelID, err := c.ParamsInt("labelId")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid label ID")
+	}
+
+	if err := h.labelService.Delete(uint(boardID), uint(labelID), userID); err != nil {
+		return labelError(c, err, "Failed to delete label")
+	}
+
+	return utils.SuccessWithMessage(c, "Label deleted successfully")
+}
+
+// Attach adds a label to a card
+func (h *LabelHandler) Attach(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	cardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card ID")
+	}
+	labelID, err := c.ParamsInt("labelId")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid label ID")
+	}
+
+	card, err := h.labelService.Attach(uint(cardID), uint(labelID), userID)
+	if err != nil {
+		return labelError(c, err, "Failed to attach label")
+	}
+
+	return utils.Success(c, toCardResponse(card))
+}
+
+// Detach removes a label from a card
+func (h *LabelHandler) Detach(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	cardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card ID")
+	}
+	labelID, err := c.ParamsInt("labelId")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid label ID")
+	}
+
+	card, err := h.labelService.Detach(uint(cardID), uint(labelID), userID)
+	if err != nil {
+		return labelError(c, err, "Failed to detach label")
+	}
+
+	return utils.Success(c, toCardResponse(card))
+}
+
+// labelError maps label service errors to responses
+func labelError(c *fiber.Ctx, err error, fallback string) error {
+	if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
+		return utils.Forbidden(c, err.Error())
+	}
+	if errors.Is(err, services.ErrLabelNotFound) || errors.Is(err, services.ErrCardNotFound) || errors.Is(err, services.ErrColumnNotFound) {
+		return utils.NotFound(c, err.Error())
+	}
+	if errors.Is(err, services.ErrInvalidColor) || errors.Is(err, services.ErrLabelNameRequired) ||
+		errors.Is(err, services.ErrLabelNameTooLong) || errors.Is(err, services.ErrLabelWrongBoard) {
+		return utils.BadRequest(c, err.Error())
+	}
+	return utils.InternalError(c, fallback)
+}
+
+// toLabelResponse converts a Label model to LabelResponse DTO
+func toLabelResponse(label *models.Label) dto.LabelResponse {
+	return dto.LabelResponse{
+		ID:      label.ID,
+		Name:    label.Name,
+		Color:   label.Color,
+		BoardID: label.BoardID,
+	}
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/icl00ud/goban/internal/services"
+	"github.com/icl00ud/goban/internal/utils"
+)
+
+// The state of a sign-in attempt is kept in a short-lived cookie that is only
+// sent back to the OIDC endpoints
+const (
+	oidcCookieName = "goban_oidc"
+	oidcCookiePath = "/api/v1/auth/oidc"
+	oidcCookieTTL  = 10 * time.Minute
+	oidcCallback   = "/api/v1/auth/oidc/callback"
+)
+
+type OIDCHandler struct {
+	oidcService    *services.OIDCService
+	sessionService *services.SessionService
+}
+
+func NewOIDCHandler(oidcService *services.OIDCService, sessionService *services.SessionService) *OIDCHandler {
+	return &OIDCHandler{
+		oidcService:    oidcService,
+		sessionService: sessionService,
+	}
+}
+
+// Login redirects the browser to the OpenID provider to sign in
+func (h *OIDCHandler) Login(c *fiber.Ctx) error {
+	authURL, state, err := h.oidcService.LoginURL(c.UserContext(), c.BaseURL()+oidcCallback)
+	if err != nil {
+		return oidcError(c, err)
+	}
+
+	c.Cookie(&fiber.Cookie{
+		Name:     oidcCookieName,
+		Value:    strings.Join([]string{state.State, state.Nonce, state.Verifier}, "."),
+		Expires:  time.Now().Add(oidcCookieTTL),
+		HTTPOnly: true,
+		Secure:   false, // Set to true in production with HTTPS
+		SameSite: "Lax",
+		Path:     oidcCookiePath,
+	})
+
+	return c.Redirect(authURL, fiber.StatusFound)
+}
+
+// Callback completes sign-in when the provider redirects back, starts a
+// session and sends the browser to the app
+func (h *OIDCHandler) Callback(c *fiber.Ctx) error {
+	state := oidcLoginState(c.Cookies(oidcCookieName))
+	c.Cookie(&fiber.Cookie{
+		Name:     oidcCookieName,
+		Value:    "",
+		Expires:  time.Now().Add(-time.Hour),
+		HTTPOnly: true,
+		SameSite: "Lax",
+		Path:     oidcCookiePath,
+	})
+
+	if providerError := c.Query("error"); providerError != "" {
+		return utils.Unauthorized(c, "Single sign-on was cancelled or denied: "+providerError)
+	}
+
+	user, err := h.oidcService.Callback(c.UserContext(), c.BaseURL()+oidcCallback, c.Query("code"), c.Query("state"), state)
+	if err != nil {
+		return oidcError(c, err)
+	}
+
+	tokens, err := h.sessionService.Start(user, c.Get(fiber.HeaderUserAgent), clientIP(c))
+	if err != nil {
+		return utils.InternalError(c, "Login failed")
+	}
+	setSessionCookies(c, tokens)
+
+	return c.Redirect("/", fiber.StatusFound)
+}
+
+// oidcLoginState parses the sign-in attempt cookie, returning nil when it is
+// missing or malformed
+func oidcLoginState(cookie string) *services.OIDCLoginState {
+	parts := strings.Split(cookie, ".")
+	if len(parts) != 3 {
+		return nil
+	}
+	return &services.OIDCLoginState{State: parts[0], Nonce: parts[1], Verifier: parts[2]}
+}
+
+// oidcError maps single sign-on service errors to responses
+func oidcError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, services.ErrOIDCDisabled) {
+		return utils.NotFound(c, err.Error())
+	}
+	if errors.Is(err, services.ErrOIDCEmailNotVerified) || errors.Is(err, services.ErrOIDCDomainNotAllowed) ||
+		errors.Is(err, services.ErrAccountDisabled) || errors.Is(err, services.ErrRegistrationClosed) ||
+		errors.Is(err, services.ErrEmailDomainNotAllowed) {
+		return utils.Forbidden(c, err.Error())
+	}
+	if errors.Is(err, services.ErrOIDCAccountUnverified) {
+		return utils.Error(c, fiber.StatusConflict, err.Error())
+	}
+	if errors.Is(err, services.ErrOIDCInvalidLoginState) {
+		return utils.BadRequest(c, err.Error())
+	}
+	if errors.Is(err, services.ErrOIDCFailed) {
+		log.Printf("Single sign-on failed: %v", err)
+		return utils.Error(c, fiber.StatusBadGateway, services.ErrOIDCFailed.Error())
+	}
+	return utils.InternalError(c, "Login failed")
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"html"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/icl00ud/goban/internal/dto"
+	"github.com/icl00ud/goban/internal/repository"
+	"github.com/icl00ud/goban/internal/services"
+	"github.com/icl00ud/goban/internal/utils"
+)
+
+// highlighter turns escaped snippet markers into HTML
+var highlighter = strings.NewReplacer(repository.HighlightStart, "<mark>", repository.HighlightEnd, "</mark>")
+
+type SearchHandler struct {
+	searchService *services.SearchService
+}
+
+func NewSearchHandler(searchService *services.SearchService) *SearchHandler {
+	return &SearchHandler{searchService: searchService}
+}
+
+// Search returns ranked boards, cards and comments matching the q parameter
+func (h *SearchHandler) Search(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		return utils.BadRequest(c, "Search query is required")
+	}
+
+	hits, err := h.searchService.Search(userID, query, c.QueryInt("limit", 0))
+	if err != nil {
+		return utils.InternalError(c, "Failed to search")
+	}
+
+	response := make([]dto.SearchHitResponse, len(hits))
+	for i, hit := range hits {
+		response[i] = dto.SearchHitResponse{
+			Type:        hit.Type,
+			ID:          hit.ID,
+			Title:       hit.Title,
+			Snippet:     highlighter.Replace(html.EscapeString(hit.Snippet)),
+			Score:       hit.Score,
+			BoardID:     hit.BoardID,
+			BoardName:   hit.BoardName,
+			ColumnID:    hit.ColumnID,
+			ColumnTitle: hit.ColumnTitle,
+			CardID:      hit.CardID,
+			CardTitle:   hit.CardTitle,
+		}
+	}
+
+	return utils.Success(c, response)
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/icl00ud/goban/internal/dto"
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/services"
+	"github.com/icl00ud/goban/internal/utils"
+)
+
+type SessionHandler struct {
+	sessionService *services.SessionService
+}
+
+func NewSessionHandler(sessionService *services.SessionService) *SessionHandler {
+	return &SessionHandler{sessionService: sessionService}
+}
+
+// List returns the current user's active sessions
+func (h *SessionHandler) List(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	currentID, _ := c.Locals("sessionID").(uint)
+
+	sessions, err := h.sessionService.List(userID)
+	if err != nil {
+		return utils.InternalError(c, "Failed to fetch sessions")
+	}
+
+	response := make([]dto.SessionResponse, len(sessions))
+	for i := range sessions {
+		response[i] = toSessionResponse(&sessions[i], currentID)
+	}
+
+	return utils.Success(c, response)
+}
+
+// Revoke signs out one of the current user's sessions
+func (h *SessionHandler) Revoke(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	currentID, _ := c.Locals("sessionID").(uint)
+	sessionID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid session ID")
+	}
+
+	if err := h.sessionService.Revoke(uint(sessionID), userID); err != nil {
+		if errors.Is(err, services.ErrSessionNotFound) {
+			return utils.NotFound(c, err.Error())
+		}
+		return utils.InternalError(c, "Failed to revoke session")
+	}
+
+	if uint(sessionID) == currentID {
+		clearSessionCookies(c)
+	}
+
+	return utils.SuccessWithMessage(c, "Session revoked")
+}
+
+// RevokeAll signs out every session of the current user, including this one
+func (h *SessionHandler) RevokeAll(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	if err := h.sessionService.RevokeAll(userID); err != nil {
+		return utils.InternalError(c, "Failed to revoke sessions")
+	}
+	clearSessionCookies(c)
+
+	return utils.SuccessWithMessage(c, "All sessions revoked")
+}
+
+// toSessionResponse converts a Session model to SessionResponse DTO
+func toSessionResponse(session *models.Session, currentID uint) dto.SessionResponse {
+	return dto.SessionResponse{
+		ID:         session.ID,
+		Device:     session.Device,
+		IPAddress:  session.IPAddress,
+		UserAgent:  session.UserAgent,
+		Current:    session.ID == currentID,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+		ExpiresAt:  session.ExpiresAt,
+	}
+}
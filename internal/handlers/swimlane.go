@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/icl00ud/goban/internal/dto"
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/services"
+	"github.com/icl00ud/goban/internal/utils"
+)
+
+type SwimlaneHandler struct {
+	swimlaneService *services.SwimlaneService
+}
+
+func NewSwimlaneHandler(swimlaneService *services.SwimlaneService) *SwimlaneHandler {
+	return &SwimlaneHandler{swimlaneService: swimlaneService}
+}
+
+// List returns all swimlanes of a board
+func (h *SwimlaneHandler) List(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	boardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board ID")
+	}
+
+	swimlanes, err := h.swimlaneService.List(uint(boardID), userID)
+	if err != nil {
+		return swimlaneError(c, err, "Failed to fetch swimlanes")
+	}
+
+	response := make([]dto.SwimlaneResponse, len(swimlanes))
+	for i, swimlane := range swimlanes {
+		response[i] = toSwimlaneResponse(&swimlane)
+	}
+
+	return utils.Success(c, response)
+}
+
+// Create creates a new swimlane on a board
+func (h *SwimlaneHandler) Create(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	boardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board ID")
+	}
+
+	var req dto.CreateSwimlaneRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return utils.BadRequest(c, "Swimlane title is required")
+	}
+
+	swimlane, err := h.swimlaneService.Create(uint(boardID), userID, title)
+	if err != nil {
+		return swimlaneError(c, err, "Failed to create swimlane")
+	}
+
+	return utils.Created(c, toSwimlaneResponse(swimlane))
+}
+
+// Update renames, collapses or expands a swimlane
+func (h *SwimlaneHandler) Update(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	boardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board ID")
+	}
+	swimlaneID, err := c.ParamsInt("swimlaneId")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid swimlane ID")
+	}
+
+	var req dto.UpdateSwimlaneRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+
+	swimlane, err := h.swimlaneService.Update(uint(boardID), uint(swimlaneID), userID, strings.TrimSpace(req.Title), req.Collapsed)
+	if err != nil {
+		return swimlaneError(c, err, "Failed to update swimlane")
+	}
+
+	return utils.Success(c, toSwimlaneResponse(swimlane))
+}
+
+// Delete deletes a swimlane
+func (h *SwimlaneHandler) Delete(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	boardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board ID")
+	}
+	swimlaneID, err := c.ParamsInt("swimlaneId")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid swimlane ID")
+	}
+
+	if err := h.swimlaneService.Delete(uint(boardID), uint(swimlaneID), userID); err != nil {
+		return swimlaneError(c, err, "Failed to delete swimlane")
+	}
+
+	return utils.SuccessWithMessage(c, "Swimlane deleted successfully")
+}
+
+// Reorder reorders the swimlanes of a board
+func (h *SwimlaneHandler) Reorder(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	boardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board ID")
+	}
+
+	var req dto.ReorderSwimlanesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+
+	if len(req.SwimlaneIDs) == 0 {
+		return utils.BadRequest(c, "swimlane_ids is required")
+	}
+
+	if err := h.swimlaneService.Reorder(uint(boardID), userID, req.SwimlaneIDs); err != nil {
+		return swimlaneError(c, err, "Failed to reorder swimlanes")
+	}
+
+	return utils.SuccessWithMessage(c, "Swimlanes reordered successfully")
+}
+
+// swimlaneError maps swimlane service errors to responses
+func swimlaneError(c *fiber.Ctx, err error, fallback string) error {
+	if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
+		return utils.Forbidden(c, err.Error())
+	}
+	if errors.Is(err, services.ErrSwimlaneNotFound) {
+		return utils.NotFound(c, err.Error())
+	}
+	return utils.InternalError(c, fallback)
+}
+
+// toSwimlaneResponse converts a Swimlane model to SwimlaneResponse DTO
+func toSwimlaneResponse(swimlane *models.Swimlane) dto.SwimlaneResponse {
+	return dto.SwimlaneResponse{
+		ID:        swimlane.ID,
+		Title:     swimlane.Title,
+		Position:  swimlane.Position,
+		Collapsed: swimlane.Collapsed,
+		BoardID:   swimlane.BoardID,
+	}
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/icl00ud/goban/internal/dto"
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/services"
+	"github.com/icl00ud/goban/internal/utils"
+)
+
+type TemplateHandler struct {
+	templateService *services.TemplateService
+}
+
+func NewTemplateHandler(templateService *services.TemplateService) *TemplateHandler {
+	return &TemplateHandler{templateService: templateService}
+}
+
+// List returns the built-in templates and the user's own templates
+func (h *TemplateHandler) List(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	templates, err := h.templateService.List(userID)
+	if err != nil {
+		return templateError(c, err, "Failed to fetch templates")
+	}
+
+	response := make([]dto.TemplateResponse, len(templates))
+	for i, template := range templates {
+		response[i] = toTemplateResponse(&template)
+	}
+
+	return utils.Success(c, response)
+}
+
+// Get returns a single template
+func (h *TemplateHandler) Get(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	templateID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid template ID")
+	}
+
+	template, err := h.templateService.GetByID(uint(templateID), userID)
+	if err != nil {
+		return templateError(c, err, "Failed to fetch template")
+	}
+
+	return utils.Success(c, toTemplateResponse(template))
+}
+
+// SaveFromBoard saves a board's structure as a new template
+func (h *TemplateHandler) SaveFromBoard(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	boardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board ID")
+	}
+
+	var req dto.SaveTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+
+	template, err := h.templateService.SaveFromBoard(uint(boardID), userID, strings.TrimSpace(req.Name), req.Description, req.IncludeCards)
+	if err != nil {
+		return templateError(c, err, "Failed to save template")
+	}
+
+	return utils.Created(c, toTemplateResponse(template))
+}
+
+// Delete deletes one of the user's templates
+func (h *TemplateHandler) Delete(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	templateID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid template ID")
+	}
+
+	if err := h.templateService.Delete(uint(templateID), userID); err != nil {
+		return templateError(c, err, "Failed to delete template")
+	}
+
+	return utils.SuccessWithMessage(c, "Template deleted successfully")
+}
+
+// templateError maps template service errors to responses
+func templateError(c *fiber.Ctx, err error, fallback string) error {
+	if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) || errors.Is(err, services.ErrTemplateBuiltIn) {
+		return utils.Forbidden(c, err.Error())
+	}
+	if errors.Is(err, services.ErrTemplateNotFound) || errors.Is(err, services.ErrBoardNotFound) {
+		return utils.NotFound(c, err.Error())
+	}
+	if errors.Is(err, services.ErrTemplateNameRequired) {
+		return utils.BadRequest(c, err.Error())
+	}
+	return utils.InternalError(c, fallback)
+}
+
+// toTemplateResponse converts a BoardTemplate model to TemplateResponse DTO
+func toTemplateResponse(template *models.BoardTemplate) dto.TemplateResponse {
+	return dto.TemplateResponse{
+		ID:          template.ID,
+		Name:        template.Name,
+		Description: template.Description,
+		BuiltIn:     template.BuiltIn,
+		Structure:   rawSnapshot(template.Structure),
+		CreatedAt:   template.CreatedAt,
+	}
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/icl00ud/goban/internal/dto"
+	"github.com/icl00ud/goban/internal/services"
+	"github.com/icl00ud/goban/internal/utils"
+)
+
+type TrashHandler struct {
+	trashService *services.TrashService
+}
+
+func NewTrashHandler(trashService *services.TrashService) *TrashHandler {
+	return &TrashHandler{trashService: trashService}
+}
+
+// List returns the deleted boards, columns and cards the user can restore
+func (h *TrashHandler) List(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	boards, columns, cards, err := h.trashService.List(userID)
+	if err != nil {
+		return utils.InternalError(c, "Failed to fetch trash")
+	}
+
+	response := dto.TrashResponse{
+		Boards:  make([]dto.TrashItemResponse, len(boards)),
+		Columns: make([]dto.TrashItemResponse, len(columns)),
+		Cards:   make([]dto.TrashItemResponse, len(cards)),
+	}
+	for i, board := range boards {
+		response.Boards[i] = dto.TrashItemResponse{
+			ID:        board.ID,
+			Title:     board.Name,
+			BoardID:   board.ID,
+			BoardName: board.Name,
+			DeletedAt: board.DeletedAt.Time,
+			PurgeAt:   h.trashService.PurgeAt(board.DeletedAt.Time),
+		}
+	}
+	for i, column := range columns {
+		response.Columns[i] = dto.TrashItemResponse{
+			ID:        column.ID,
+			Title:     column.Title,
+			BoardID:   column.BoardID,
+			BoardName: column.Board.Name,
+			DeletedAt: column.DeletedAt.Time,
+			PurgeAt:   h.trashService.PurgeAt(column.DeletedAt.Time),
+		}
+	}
+	for i, card := range cards {
+		response.Cards[i] = dto.TrashItemResponse{
+			ID:          card.ID,
+			Title:       card.Title,
+			BoardID:     card.Column.BoardID,
+			BoardName:   card.Column.Board.Name,
+			ColumnID:    card.ColumnID,
+			ColumnTitle: card.Column.Title,
+			DeletedAt:   card.DeletedAt.Time,
+			PurgeAt:     h.trashService.PurgeAt(card.DeletedAt.Time),
+		}
+	}
+
+	return utils.Success(c, response)
+}
+
+// RestoreBoard restores a deleted board with its columns and cards
+func (h *TrashHandler) RestoreBoard(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	boardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board ID")
+	}
+
+	board, err := h.trashService.RestoreBoard(uint(boardID), userID)
+	if err != nil {
+		return trashError(c, err, "Failed to restore board")
+	}
+
+	return utils.Success(c, toBoardResponse(board))
+}
+
+// RestoreColumn restores a deleted column with its cards
+func (h *TrashHandler) RestoreColumn(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	columnID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid column ID")
+	}
+
+	column, err := h.trashService.RestoreColumn(uint(columnID), userID)
+	if err != nil {
+		return trashError(c, err, "Failed to restore column")
+	}
+
+	response := dto.ColumnResponse{
+		ID:       column.ID,
+		Title:    column.Title,
+		Position: column.Position,
+		BoardID:  column.BoardID,
+		WIPLimit: column.WIPLimit,
+		Cards:    make([]dto.CardResponse, len(column.Cards)),
+	}
+	for i, card := range column.Cards {
+		response.Cards[i] = toCardResponse(&card)
+	}
+
+	return utils.Success(c, response)
+}
+
+// RestoreCard restores a deleted card
+func (h *TrashHandler) RestoreCard(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	cardID, err := c.ParamsInt("id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card ID")
+	}
+
+	card, err := h.trashService.RestoreCard(uint(cardID), userID)
+	if err != nil {
+		return trashError(c, err, "Failed to restore card")
+	}
+
+	return utils.Success(c, toCardResponse(card))
+}
+
+// trashError maps trash service errors to responses
+func trashError(c *fiber.Ctx, err error, fallback string) error {
+	if errors.Is(err, services.ErrNotBoardOwner) || errors.Is(err, services.ErrInsufficientRole) {
+		return utils.Forbidden(c, err.Error())
+	}
+	if errors.Is(err, services.ErrTrashItemNotFound) {
+		return utils.NotFound(c, err.Error())
+	}
+	if errors.Is(err, services.ErrTrashParentDeleted) || errors.Is(err, services.ErrWIPLimitExceeded) {
+		return utils.Error(c, fiber.StatusConflict, err.Error())
+	}
+	return utils.InternalError(c, fallback)
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/icl00ud/goban/internal/dto"
+	"github.com/icl00ud/goban/internal/services"
+	"github.com/icl00ud/goban/internal/utils"
+)
+
+type TwoFactorHandler struct {
+	twoFactorService *services.TwoFactorService
+}
+
+func NewTwoFactorHandler(twoFactorService *services.TwoFactorService) *TwoFactorHandler {
+	return &TwoFactorHandler{twoFactorService: twoFactorService}
+}
+
+// Status returns whether two-factor authentication is enabled
+func (h *TwoFactorHandler) Status(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	enabled, remaining, err := h.twoFactorService.Status(userID)
+	if err != nil {
+		return utils.InternalError(c, "Failed to fetch two-factor status")
+	}
+
+	return utils.Success(c, dto.TwoFactorStatusResponse{Enabled: enabled, RecoveryCodesRemaining: remaining})
+}
+
+// Setup starts TOTP enrolment and returns the secret to add to an authenticator
+func (h *TwoFactorHandler) Setup(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	secret, uri, err := h.twoFactorService.Setup(userID)
+	if err != nil {
+		return twoFactorError(c, err, "Failed to start two-factor setup")
+	}
+
+	return utils.Success(c, dto.TwoFactorSetupResponse{Secret: secret, OTPAuthURI: uri})
+}
+
+// Confirm enables two-factor authentication with a code from the
+// authenticator and returns the recovery codes
+func (h *TwoFactorHandler) Confirm(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	var req dto.TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+
+	codes, err := h.twoFactorService.Confirm(userID, req.Code)
+	if err != nil {
+		return twoFactorError(c, err, "Failed to enable two-factor authentication")
+	}
+
+	return utils.Success(c, dto.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// Disable turns two-factor authentication off
+func (h *TwoFactorHandler) Disable(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	var req dto.TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+
+	if err := h.twoFactorService.Disable(userID, req.Code); err != nil {
+		return twoFactorError(c, err, "Failed to disable two-factor authentication")
+	}
+
+	return utils.SuccessWithMessage(c, "Two-factor authentication disabled")
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	var req dto.TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body")
+	}
+
+	codes, err := h.twoFactorService.RegenerateRecoveryCodes(userID, req.Code)
+	if err != nil {
+		return twoFactorError(c, err, "Failed to regenerate recovery codes")
+	}
+
+	return utils.Success(c, dto.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// twoFactorError maps two-factor service errors to responses
+func twoFactorError(c *fiber.Ctx, err error, fallback string) error {
+	if errors.Is(err, services.ErrTwoFactorAlreadyEnabled) {
+		return utils.Error(c, fiber.StatusConflict, err.Error())
+	}
+	if errors.Is(err, services.ErrTwoFactorNotEnabled) || errors.Is(err, services.ErrTwoFactorNotStarted) ||
+		errors.Is(err, services.ErrInvalidTwoFactorCode) {
+		return utils.BadRequest(c, err.Error())
+	}
+	if errors.Is(err, services.ErrInvalidLoginChallenge) {
+		return utils.Unauthorized(c, err.Error())
+	}
+	var locked *services.AccountLockedError
+	if errors.As(err, &locked) {
+		return accountLocked(c, locked)
+	}
+	return utils.InternalError(c, fallback)
+}
@@ -0,0 +1,50 @@
+package mail
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+)
+
+// Message templates. The first line of each is the subject and the rest is
+// the body.
+var templates = template.Must(template.New("mail").Parse(`
+{{define "verify_email"}}Verify your email address for Goban
+Hi {{.Name}},
+
+Please confirm that {{.Email}} is your email address by opening this link:
+
+{{.Link}}
+
+The link expires in {{.ExpiresIn}}. If you didn't create a Goban account, you can ignore this email.
+{{end}}
+
+{{define "reset_password"}}Reset your Goban password
+Hi {{.Name}},
+
+We received a request to reset the password of your Goban account. Choose a new password here:
+
+{{.Link}}
+
+The link expires in {{.ExpiresIn}} and can only be used once. If you didn't ask for this, you can ignore this email; your password won't change.
+{{end}}
+`))
+
+// TemplateData fills in a message template
+type TemplateData struct {
+	Name      string
+	Email     string
+	Link      string
+	ExpiresIn string
+}
+
+// Render builds a message to the recipient from a named template
+func Render(name, to string, data TemplateData) (*Message, error) {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return nil, err
+	}
+
+	subject, body, _ := strings.Cut(buf.String(), "\n")
+	return &Message{To: to, Subject: subject, Body: body}, nil
+}
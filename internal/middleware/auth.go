@@ -1,22 +1,32 @@
 package middleware
 
 import (
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/icl00ud/goban/internal/handlers"
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/services"
 	"github.com/icl00ud/goban/internal/utils"
 )
 
-// AuthMiddleware validates JWT tokens from cookies
-func AuthMiddleware(jwtSecret string) fiber.Handler {
+// AuthMiddleware authenticates requests with a personal API token sent as
+// "Authorization: Bearer", or otherwise with the access token cookie of an
+// active session
+func AuthMiddleware(sessionService *services.SessionService, tokenService *services.APITokenService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		if bearer, ok := bearerToken(c); ok {
+			return apiTokenAuth(c, tokenService, bearer)
+		}
+
 		// Get token from cookie
 		token := c.Cookies(handlers.CookieName)
 		if token == "" {
 			return utils.Unauthorized(c, "Authentication required")
 		}
 
-		// Validate token
-		claims, err := utils.ValidateToken(token, jwtSecret)
+		// Validate token and its session, which may have been revoked
+		session, claims, err := sessionService.Authenticate(token)
 		if err != nil {
 			return utils.Unauthorized(c, "Invalid or expired token")
 		}
@@ -24,7 +34,63 @@ func AuthMiddleware(jwtSecret string) fiber.Handler {
 		// Store user info in context
 		c.Locals("userID", claims.UserID)
 		c.Locals("email", claims.Email)
+		c.Locals("sessionID", session.ID)
+
+		return c.Next()
+	}
+}
 
+// SessionOnly rejects requests authenticated with an API token, for endpoints
+// such as token and session management that need an interactive login
+func SessionOnly() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Locals("apiTokenID") != nil {
+			return utils.Forbidden(c, "This endpoint cannot be used with an API token")
+		}
 		return c.Next()
 	}
 }
+
+// AdminOnly rejects requests from users who are not instance administrators.
+// It must run after AuthMiddleware.
+func AdminOnly(adminService *services.AdminService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !adminService.IsAdmin(c.Locals("userID").(uint)) {
+			return utils.Forbidden(c, services.ErrNotAdmin.Error())
+		}
+		return c.Next()
+	}
+}
+
+// apiTokenAuth authenticates a request with a personal API token. Read-scoped
+// tokens may only make safe requests.
+func apiTokenAuth(c *fiber.Ctx, tokenService *services.APITokenService, secret string) error {
+	token, err := tokenService.Authenticate(secret)
+	if err != nil {
+		return utils.Unauthorized(c, "Invalid or expired API token")
+	}
+
+	if token.Scope == models.TokenScopeRead && !isSafeMethod(c.Method()) {
+		return utils.Forbidden(c, "This API token only allows read access")
+	}
+
+	c.Locals("userID", token.UserID)
+	c.Locals("email", token.User.Email)
+	c.Locals("apiTokenID", token.ID)
+
+	return c.Next()
+}
+
+// bearerToken extracts the credentials of an "Authorization: Bearer" header
+func bearerToken(c *fiber.Ctx) (string, bool) {
+	scheme, credentials, found := strings.Cut(c.Get(fiber.HeaderAuthorization), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	credentials = strings.TrimSpace(credentials)
+	return credentials, credentials != ""
+}
+
+func isSafeMethod(method string) bool {
+	return method == fiber.MethodGet || method == fiber.MethodHead || method == fiber.MethodOptions
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"log"
+	"net"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ClientIP stores the address a request comes from in the "clientIP" local.
+// Requests from a trusted proxy are attributed to the rightmost address in
+// X-Forwarded-For that is not a trusted proxy itself; addresses further left
+// are whatever the client sent and cannot be relied on.
+func ClientIP(trustedProxies []string) fiber.Handler {
+	trusted := parseTrustedProxies(trustedProxies)
+
+	return func(c *fiber.Ctx) error {
+		c.Locals("clientIP", clientIP(c.Context().RemoteIP(), c.Request().Header.PeekAll(fiber.HeaderXForwardedFor), trusted))
+		return c.Next()
+	}
+}
+
+// requestIP returns the address ClientIP stored, or the peer address when it
+// did not run
+func requestIP(c *fiber.Ctx) string {
+	if ip, ok := c.Locals("clientIP").(string); ok {
+		return ip
+	}
+	return c.IP()
+}
+
+// clientIP walks the X-Forwarded-For hops from the right for as long as the
+// address they came from is a trusted proxy
+func clientIP(remote net.IP, forwarded [][]byte, trusted []*net.IPNet) string {
+	ip := remote
+	if !isTrusted(ip, trusted) {
+		return ip.String()
+	}
+
+	var hops []string
+	for _, header := range forwarded {
+		hops = append(hops, strings.Split(string(header), ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			break
+		}
+		ip = hop
+		if !isTrusted(ip, trusted) {
+			break
+		}
+	}
+	return ip.String()
+}
+
+func isTrusted(ip net.IP, trusted []*net.IPNet) bool {
+	for _, network := range trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTrustedProxies parses IP addresses and CIDR ranges, skipping invalid
+// entries
+func parseTrustedProxies(proxies []string) []*net.IPNet {
+	var networks []*net.IPNet
+	for _, proxy := range proxies {
+		if ip := net.ParseIP(proxy); ip != nil {
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			log.Printf("Ignoring invalid TRUSTED_PROXIES entry %q", proxy)
+			continue
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net"
+	"testing"
+)
+
+func TestClientIP(t *testing.T) {
+	trusted := parseTrustedProxies([]string{"10.0.0.1", "172.16.0.0/12", "::1", "not-an-ip"})
+
+	tests := []struct {
+		name      string
+		remote    string
+		forwarded []string
+		want      string
+	}{
+		{"direct client", "203.0.113.7", nil, "203.0.113.7"},
+		{"header from untrusted peer is ignored", "203.0.113.7", []string{"198.51.100.1"}, "203.0.113.7"},
+		{"trusted proxy", "10.0.0.1", []string{"198.51.100.1"}, "198.51.100.1"},
+		{"spoofed entries left of the client are ignored", "10.0.0.1", []string{"1.2.3.4, 5.6.7.8, 198.51.100.1"}, "198.51.100.1"},
+		{"chain of trusted proxies", "10.0.0.1", []string{"1.2.3.4, 198.51.100.1, 172.20.0.5"}, "198.51.100.1"},
+		{"repeated headers", "10.0.0.1", []string{"1.2.3.4", "198.51.100.1"}, "198.51.100.1"},
+		{"invalid hop ends the chain", "10.0.0.1", []string{"198.51.100.1, garbage, 172.20.0.5"}, "172.20.0.5"},
+		{"trusted proxy without header", "10.0.0.1", nil, "10.0.0.1"},
+		{"IPv6 proxy", "::1", []string{"2001:db8::1"}, "2001:db8::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var forwarded [][]byte
+			for _, header := range tt.forwarded {
+				forwarded = append(forwarded, []byte(header))
+			}
+			if got := clientIP(net.ParseIP(tt.remote), forwarded, trusted); got != tt.want {
+				t.Errorf("clientIP = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+	"github.com/icl00ud/goban/internal/utils"
+)
+
+// RateLimitByIP allows max requests per client IP in each window. Handlers
+// created by one call share their counters, so the routes they guard draw
+// from the same budget. A max of 0 disables the limit.
+func RateLimitByIP(max int, window time.Duration) fiber.Handler {
+	return rateLimit(max, window, func(c *fiber.Ctx) string {
+		return "ip:" + requestIP(c)
+	})
+}
+
+// RateLimitByAccount allows max requests per route for the email address in
+// the request body, however many clients they come from. Requests without an
+// email are left to the handler to reject.
+func RateLimitByAccount(max int, window time.Duration) fiber.Handler {
+	return rateLimit(max, window, func(c *fiber.Ctx) string {
+		var body struct {
+			Email string `json:"email"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return ""
+		}
+		email := strings.ToLower(strings.TrimSpace(body.Email))
+		if email == "" {
+			return ""
+		}
+		return "account:" + c.Path() + ":" + email
+	})
+}
+
+// RateLimitByUser allows max requests per authenticated user in each window.
+// It must run after AuthMiddleware.
+func RateLimitByUser(max int, window time.Duration) fiber.Handler {
+	return rateLimit(max, window, func(c *fiber.Ctx) string {
+		userID, ok := c.Locals("userID").(uint)
+		if !ok {
+			return ""
+		}
+		return fmt.Sprintf("user:%d", userID)
+	})
+}
+
+// rateLimit builds a fixed window limiter keyed by key. Requests with an
+// empty key are not counted.
+func rateLimit(max int, window time.Duration, key func(c *fiber.Ctx) string) fiber.Handler {
+	if max <= 0 {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	return limiter.New(limiter.Config{
+		Max:          max,
+		Expiration:   window,
+		Next:         func(c *fiber.Ctx) bool { return key(c) == "" },
+		KeyGenerator: key,
+		LimitReached: func(c *fiber.Ctx) error {
+			return utils.Error(c, fiber.StatusTooManyRequests, "Too many requests, try again later")
+		},
+	})
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// Activity records a change made to a board, column or card. Before and
+// After hold JSON snapshots of the entity and are empty when not applicable.
+type Activity struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	BoardID    uint      `gorm:"not null;index" json:"board_id"`
+	UserID     uint      `gorm:"not null;index" json:"user_id"`
+	EntityType string    `gorm:"type:varchar(20);not null;index:idx_activity_entity" json:"entity_type"`
+	EntityID   uint      `gorm:"not null;index:idx_activity_entity" json:"entity_id"`
+	Action     string    `gorm:"type:varchar(20);not null" json:"action"`
+	Before     string    `gorm:"type:text" json:"before"`
+	After      string    `gorm:"type:text" json:"after"`
+	CreatedAt  time.Time `json:"created_at"`
+	User       User      `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// Activity entity types
+const (
+	EntityBoard    = "board"
+	EntityColumn   = "column"
+	EntityCard     = "card"
+	EntitySwimlane = "swimlane"
+)
+
+// Activity actions
+const (
+	ActionCreated    = "created"
+	ActionUpdated    = "updated"
+	ActionDeleted    = "deleted"
+	ActionRestored   = "restored"
+	ActionMoved      = "moved"
+	ActionReordered  = "reordered"
+	ActionAssigned   = "assigned"
+	ActionUnassigned = "unassigned"
+)
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// APIToken is a personal access token a user creates for scripts and CI. Only
+// a hash of the token is stored; the token itself is shown once on creation.
+type APIToken struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	Name       string     `gorm:"type:varchar(100);not null" json:"name"`
+	TokenHash  string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	Prefix     string     `gorm:"type:varchar(16);not null" json:"prefix"`
+	Scope      string     `gorm:"type:varchar(10);not null" json:"scope"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	UserID     uint       `gorm:"not null;index" json:"user_id"`
+	CreatedAt  time.Time  `json:"created_at"`
+	User       User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// API token scopes
+const (
+	TokenScopeRead  = "read"
+	TokenScopeWrite = "write"
+)
+
+// ValidateTokenScope checks if the scope is a known API token scope
+func ValidateTokenScope(scope string) bool {
+	return scope == TokenScopeRead || scope == TokenScopeWrite
+}
+
+// Expired reports whether the token has passed its expiry
+func (t *APIToken) Expired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Attachment is a file uploaded to a card. The contents live in the storage
+// backend under StorageKey.
+type Attachment struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	CardID      uint      `gorm:"not null;index" json:"card_id"`
+	UserID      uint      `gorm:"not null" json:"user_id"`
+	FileName    string    `gorm:"size:255;not null" json:"file_name"`
+	ContentType string    `gorm:"size:255;not null" json:"content_type"`
+	Size        int64     `gorm:"not null" json:"size"`
+	StorageKey  string    `gorm:"size:255;not null;uniqueIndex" json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+	Card        Card      `gorm:"foreignKey:CardID;constraint:OnDelete:CASCADE" json:"-"`
+	User        User      `gorm:"foreignKey:UserID" json:"-"`
+}
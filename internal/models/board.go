@@ -7,15 +7,34 @@ import (
 )
 
 type Board struct {
-	ID          uint           `gorm:"primaryKey" json:"id"`
-	Name        string         `gorm:"type:varchar(100);not null" json:"name"`
-	Description string         `gorm:"type:text" json:"description"`
-	Color       string         `gorm:"type:varchar(7);default:'#3b82f6'" json:"color"`
-	Position    int            `gorm:"default:0" json:"position"`
-	UserID      uint           `gorm:"not null;index" json:"user_id"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
-	User        User           `gorm:"foreignKey:UserID" json:"-"`
-	Columns     []Column       `gorm:"foreignKey:BoardID;constraint:OnDelete:CASCADE" json:"columns,omitempty"`
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	Name         string         `gorm:"type:varchar(100);not null" json:"name"`
+	Description  string         `gorm:"type:text" json:"description"`
+	Color        string         `gorm:"type:varchar(7);default:'#3b82f6'" json:"color"`
+	Position     int            `gorm:"default:0" json:"position"`
+	WIPLimitMode string         `gorm:"type:varchar(10);not null;default:'warn'" json:"wip_limit_mode"`
+	UserID       uint           `gorm:"not null;index" json:"user_id"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+	User         User           `gorm:"foreignKey:UserID" json:"-"`
+	Columns      []Column       `gorm:"foreignKey:BoardID;constraint:OnDelete:CASCADE" json:"columns,omitempty"`
+	Swimlanes    []Swimlane     `gorm:"foreignKey:BoardID;constraint:OnDelete:CASCADE" json:"swimlanes,omitempty"`
+}
+
+// WIP limit enforcement modes. Warn allows exceeding a column's limit and
+// flags the card; reject refuses the change.
+const (
+	WIPLimitWarn   = "warn"
+	WIPLimitReject = "reject"
+)
+
+// ValidateWIPLimitMode checks if the WIP limit mode value is valid
+func ValidateWIPLimitMode(mode string) bool {
+	switch mode {
+	case WIPLimitWarn, WIPLimitReject:
+		return true
+	default:
+		return false
+	}
 }
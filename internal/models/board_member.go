@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+)
+
+type BoardMember struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	BoardID   uint      `gorm:"not null;uniqueIndex:idx_board_member" json:"board_id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_board_member;index" json:"user_id"`
+	Role      string    `gorm:"type:varchar(20);not null;default:'viewer'" json:"role"`
+	Position  int       `gorm:"default:0" json:"-"` // where the member sorts the board on their dashboard
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Board     Board     `gorm:"foreignKey:BoardID;constraint:OnDelete:CASCADE" json:"-"`
+	User      User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// Role constants
+const (
+	RoleViewer = "viewer"
+	RoleEditor = "editor"
+	RoleOwner  = "owner"
+)
+
+// roleRank orders roles from least to most privileged
+var roleRank = map[string]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleOwner:  3,
+}
+
+// ValidateRole checks if the role value is valid
+func ValidateRole(role string) bool {
+	_, ok := roleRank[role]
+	return ok
+}
+
+// RoleAtLeast reports whether role grants at least the permissions of minRole
+func RoleAtLeast(role, minRole string) bool {
+	return roleRank[role] > 0 && roleRank[role] >= roleRank[minRole]
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// BoardTemplate is a reusable board structure. Built-in templates ship with
+// the application and have no owner.
+type BoardTemplate struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Name        string    `gorm:"type:varchar(100);not null" json:"name"`
+	Description string    `gorm:"type:text" json:"description"`
+	BuiltIn     bool      `gorm:"not null;default:false;index" json:"built_in"`
+	UserID      *uint     `gorm:"index" json:"user_id"`
+	Structure   string    `gorm:"type:text;not null" json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TemplateStructure is the board layout a template recreates, stored as JSON
+type TemplateStructure struct {
+	WIPLimitMode string           `json:"wip_limit_mode,omitempty"`
+	Columns      []TemplateColumn `json:"columns"`
+	Labels       []TemplateLabel  `json:"labels,omitempty"`
+	Swimlanes    []string         `json:"swimlanes,omitempty"`
+}
+
+// TemplateColumn is a column of a template with its optional sample cards
+type TemplateColumn struct {
+	Title    string         `json:"title"`
+	WIPLimit *int           `json:"wip_limit,omitempty"`
+	Cards    []TemplateCard `json:"cards,omitempty"`
+}
+
+// TemplateLabel is a board label of a template
+type TemplateLabel struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// TemplateCard is a sample card of a template. Labels and Swimlane refer to
+// the template's labels and swimlanes by name.
+type TemplateCard struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Priority    string   `json:"priority,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+	Swimlane    string   `json:"swimlane,omitempty"`
+}
@@ -7,16 +7,25 @@ import (
 )
 
 type Card struct {
-	ID          uint           `gorm:"primaryKey" json:"id"`
-	Title       string         `gorm:"type:varchar(200);not null" json:"title"`
-	Description string         `gorm:"type:text" json:"description"`
-	Position    int            `gorm:"not null;default:0" json:"position"`
-	Priority    string         `gorm:"type:varchar(20);default:'medium'" json:"priority"`
-	ColumnID    uint           `gorm:"not null;index" json:"column_id"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
-	Column      Column         `gorm:"foreignKey:ColumnID" json:"-"`
+	ID             uint           `gorm:"primaryKey" json:"id"`
+	Title          string         `gorm:"type:varchar(200);not null" json:"title"`
+	Description    string         `gorm:"type:text" json:"description"`
+	Position       int            `gorm:"not null;default:0" json:"position"`
+	Priority       string         `gorm:"type:varchar(20);default:'medium'" json:"priority"`
+	ColumnID       uint           `gorm:"not null;index" json:"column_id"`
+	SwimlaneID     *uint          `gorm:"index" json:"swimlane_id"`
+	StartAt        *time.Time     `json:"start_at"`
+	DueAt          *time.Time     `gorm:"index" json:"due_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
+	CommentCount   int            `gorm:"->;-:migration" json:"comment_count"`
+	ChecklistDone  int            `gorm:"->;-:migration" json:"checklist_done"`
+	ChecklistTotal int            `gorm:"->;-:migration" json:"checklist_total"`
+	WIPExceeded    bool           `gorm:"-" json:"wip_limit_exceeded,omitempty"`
+	Column         Column         `gorm:"foreignKey:ColumnID" json:"-"`
+	Assignees      []User         `gorm:"many2many:card_assignees;constraint:OnDelete:CASCADE" json:"assignees,omitempty"`
+	Labels         []Label        `gorm:"many2many:card_labels;constraint:OnDelete:CASCADE" json:"labels,omitempty"`
 }
 
 // Priority constants
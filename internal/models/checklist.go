@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+type Checklist struct {
+	ID        uint            `gorm:"primaryKey" json:"id"`
+	Title     string          `gorm:"type:varchar(200);not null" json:"title"`
+	Position  int             `gorm:"not null;default:0" json:"position"`
+	CardID    uint            `gorm:"not null;index" json:"card_id"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	Card      Card            `gorm:"foreignKey:CardID;constraint:OnDelete:CASCADE" json:"-"`
+	Items     []ChecklistItem `gorm:"foreignKey:ChecklistID;constraint:OnDelete:CASCADE" json:"items,omitempty"`
+}
+
+type ChecklistItem struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Text        string    `gorm:"type:varchar(500);not null" json:"text"`
+	Done        bool      `gorm:"not null;default:false" json:"done"`
+	Position    int       `gorm:"not null;default:0" json:"position"`
+	ChecklistID uint      `gorm:"not null;index" json:"checklist_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -11,6 +11,7 @@ type Column struct {
 	Title     string         `gorm:"type:varchar(100);not null" json:"title"`
 	Position  int            `gorm:"not null;default:0" json:"position"`
 	BoardID   uint           `gorm:"not null;index" json:"board_id"`
+	WIPLimit  *int           `json:"wip_limit"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
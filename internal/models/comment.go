@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Comment struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	Body      string         `gorm:"type:text;not null" json:"body"`
+	CardID    uint           `gorm:"not null;index" json:"card_id"`
+	UserID    uint           `gorm:"not null;index" json:"user_id"`
+	EditedAt  *time.Time     `json:"edited_at"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	Card      Card           `gorm:"foreignKey:CardID;constraint:OnDelete:CASCADE" json:"-"`
+	User      User           `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// CommentRevision keeps the previous body of a comment each time it is
+// edited or deleted
+type CommentRevision struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CommentID uint      `gorm:"not null;index" json:"comment_id"`
+	Action    string    `gorm:"type:varchar(20);not null" json:"action"`
+	Body      string    `gorm:"type:text;not null" json:"body"`
+	UserID    uint      `gorm:"not null" json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+	User      User      `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// Comment revision actions
+const (
+	RevisionEdited  = "edited"
+	RevisionDeleted = "deleted"
+)
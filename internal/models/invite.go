@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Invite lets one person register while registration is invite-only. Only a
+// hash of the code is stored; the code itself is shown once on creation.
+type Invite struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	CodeHash    string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	Email       string     `gorm:"type:varchar(255)" json:"email"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	UsedAt      *time.Time `json:"used_at"`
+	UsedByID    *uint      `json:"used_by_id"`
+	CreatedByID uint       `gorm:"not null;index" json:"created_by_id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CreatedBy   User       `gorm:"foreignKey:CreatedByID" json:"-"`
+	UsedBy      *User      `gorm:"foreignKey:UsedByID" json:"-"`
+}
+
+// Usable reports whether the invite can still be used to register
+func (i *Invite) Usable(now time.Time) bool {
+	return i.UsedAt == nil && (i.ExpiresAt == nil || now.Before(*i.ExpiresAt))
+}
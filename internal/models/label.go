@@ -0,0 +1,23 @@
+package models
+
+import (
+	"regexp"
+	"time"
+)
+
+type Label struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"type:varchar(50);not null" json:"name"`
+	Color     string    `gorm:"type:varchar(7);not null" json:"color"`
+	BoardID   uint      `gorm:"not null;index" json:"board_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Board     Board     `gorm:"foreignKey:BoardID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+var hexColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// ValidateColor checks if the value is a #rrggbb hex color
+func ValidateColor(color string) bool {
+	return hexColorPattern.MatchString(color)
+}
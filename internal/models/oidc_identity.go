@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// OIDCIdentity links a user to their account at an OpenID provider
+type OIDCIdentity struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Issuer    string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_oidc_identity" json:"issuer"`
+	Subject   string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_oidc_identity" json:"subject"`
+	UserID    uint      `gorm:"not null;index" json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+	User      User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
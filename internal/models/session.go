@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Session is a login on one device. Access tokens carry the session's JTI and
+// are only accepted while it is active; the refresh token is rotated on every
+// use and only its hash is stored.
+type Session struct {
+	ID                  uint       `gorm:"primaryKey" json:"id"`
+	JTI                 string     `gorm:"column:jti;type:varchar(64);uniqueIndex;not null" json:"-"`
+	RefreshTokenHash    string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	PreviousRefreshHash string     `gorm:"type:varchar(64);index" json:"-"`
+	UserID              uint       `gorm:"not null;index" json:"user_id"`
+	Device              string     `gorm:"type:varchar(100)" json:"device"`
+	IPAddress           string     `gorm:"type:varchar(45)" json:"ip_address"`
+	UserAgent           string     `gorm:"type:varchar(255)" json:"user_agent"`
+	RefreshedAt         time.Time  `json:"refreshed_at"`
+	LastSeenAt          time.Time  `json:"last_seen_at"`
+	ExpiresAt           time.Time  `gorm:"index" json:"expires_at"`
+	RevokedAt           *time.Time `json:"revoked_at"`
+	CreatedAt           time.Time  `json:"created_at"`
+	User                User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// Active reports whether the session can still be used
+func (s *Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
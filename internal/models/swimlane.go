@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// Swimlane is a horizontal row of a board that groups cards across columns
+type Swimlane struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Title     string    `gorm:"type:varchar(100);not null" json:"title"`
+	Position  int       `gorm:"not null;default:0" json:"position"`
+	Collapsed bool      `gorm:"not null;default:false" json:"collapsed"`
+	BoardID   uint      `gorm:"not null;index" json:"board_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Board     Board     `gorm:"foreignKey:BoardID;constraint:OnDelete:CASCADE" json:"-"`
+}
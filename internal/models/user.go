@@ -7,12 +7,20 @@ import (
 )
 
 type User struct {
-	ID           uint           `gorm:"primaryKey" json:"id"`
-	Email        string         `gorm:"type:varchar(255);uniqueIndex;not null" json:"email"`
-	PasswordHash string         `gorm:"type:varchar(255);not null" json:"-"`
-	Name         string         `gorm:"type:varchar(100);not null" json:"name"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
-	Boards       []Board        `gorm:"foreignKey:UserID" json:"boards,omitempty"`
+	ID              uint           `gorm:"primaryKey" json:"id"`
+	Email           string         `gorm:"type:varchar(255);uniqueIndex;not null" json:"email"`
+	PasswordHash    string         `gorm:"type:varchar(255);not null" json:"-"`
+	Name            string         `gorm:"type:varchar(100);not null" json:"name"`
+	IsAdmin         bool           `gorm:"not null;default:false" json:"is_admin"`
+	DisabledAt      *time.Time     `json:"disabled_at"`
+	TOTPSecret      string         `gorm:"column:totp_secret;type:varchar(64)" json:"-"`
+	TOTPEnabled     bool           `gorm:"column:totp_enabled;not null;default:false" json:"-"`
+	TOTPLastStep    int64          `gorm:"column:totp_last_step;not null;default:0" json:"-"`
+	EmailVerifiedAt *time.Time     `json:"email_verified_at"`
+	FailedLogins    int            `gorm:"not null;default:0" json:"-"`
+	LockedUntil     *time.Time     `json:"-"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+	Boards          []Board        `gorm:"foreignKey:UserID" json:"boards,omitempty"`
 }
@@ -0,0 +1,333 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testClientID     = "goban"
+	testClientSecret = "secret"
+	testRedirectURL  = "http://goban.test/api/v1/auth/oidc/callback"
+	testCode         = "good-code"
+)
+
+// mockProvider is a local OpenID provider that issues ID tokens for one
+// authorization code
+type mockProvider struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu        sync.Mutex
+	issuer    string                     // issuer reported by discovery, the server URL when empty
+	keys      map[string]*rsa.PrivateKey // published signing keys by ID
+	signKey   string                     // ID of the key ID tokens are signed with
+	nonce     string                     // nonce put in issued ID tokens
+	challenge string                     // PKCE challenge the code was issued for
+	jwksHits  int
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	t.Helper()
+
+	m := &mockProvider{
+		t:       t,
+		keys:    map[string]*rsa.PrivateKey{"key-1": generateKey(t)},
+		signKey: "key-1",
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", m.discovery)
+	mux.HandleFunc("/jwks", m.jwks)
+	mux.HandleFunc("/token", m.token)
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+
+	return m
+}
+
+func (m *mockProvider) provider() *Provider {
+	return NewProvider(Config{
+		Issuer:       m.server.URL,
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+	})
+}
+
+func (m *mockProvider) discovery(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	issuer := m.issuer
+	m.mu.Unlock()
+	if issuer == "" {
+		issuer = m.server.URL
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 issuer,
+		"authorization_endpoint": m.server.URL + "/authorize",
+		"token_endpoint":         m.server.URL + "/token",
+		"jwks_uri":               m.server.URL + "/jwks",
+	})
+}
+
+func (m *mockProvider) jwks(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jwksHits++
+
+	keys := []map[string]string{}
+	for kid, key := range m.keys {
+		keys = append(keys, map[string]string{
+			"kty": "RSA",
+			"kid": kid,
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"keys": keys})
+}
+
+// token redeems the authorization code, checking the client credentials and
+// the PKCE verifier
+func (m *mockProvider) token(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	clientID, clientSecret, _ := r.BasicAuth()
+	if clientID != testClientID || clientSecret != testClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	verifier := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+	if r.PostFormValue("grant_type") != "authorization_code" || r.PostFormValue("code") != testCode ||
+		r.PostFormValue("redirect_uri") != testRedirectURL ||
+		base64.RawURLEncoding.EncodeToString(verifier[:]) != m.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant", "error_description": "bad code"})
+		return
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            m.server.URL,
+		"aud":            testClientID,
+		"sub":            "user-42",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          m.nonce,
+		"email":          "ada@example.com",
+		"email_verified": "true",
+		"name":           "Ada Lovelace",
+	})
+	token.Header["kid"] = m.signKey
+
+	key, ok := m.keys[m.signKey]
+	if !ok {
+		key = generateKey(m.t)
+	}
+	idToken, err := token.SignedString(key)
+	if err != nil {
+		m.t.Errorf("failed to sign ID token: %v", err)
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"access_token": "at", "token_type": "Bearer", "id_token": idToken})
+}
+
+// authorize starts a sign-in the way the browser would, recording the PKCE
+// challenge and the nonce the provider puts in the ID token
+func (m *mockProvider) authorize(p *Provider, state, nonce, verifier string) url.Values {
+	m.t.Helper()
+
+	authURL, err := p.AuthCodeURL(context.Background(), testRedirectURL, state, nonce, verifier)
+	if err != nil {
+		m.t.Fatalf("AuthCodeURL: %v", err)
+	}
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		m.t.Fatalf("invalid authorization URL %q: %v", authURL, err)
+	}
+
+	query := parsed.Query()
+	m.mu.Lock()
+	m.challenge = query.Get("code_challenge")
+	m.nonce = query.Get("nonce")
+	m.mu.Unlock()
+	return query
+}
+
+func (m *mockProvider) jwksRequests() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.jwksHits
+}
+
+func generateKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return key
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	mock := newMockProvider(t)
+	p := mock.provider()
+
+	query := mock.authorize(p, "the-state", "the-nonce", "the-verifier")
+
+	challenge := sha256.Sum256([]byte("the-verifier"))
+	want := map[string]string{
+		"response_type":         "code",
+		"client_id":             testClientID,
+		"redirect_uri":          testRedirectURL,
+		"state":                 "the-state",
+		"nonce":                 "the-nonce",
+		"code_challenge":        base64.RawURLEncoding.EncodeToString(challenge[:]),
+		"code_challenge_method": "S256",
+	}
+	for name, value := range want {
+		if got := query.Get(name); got != value {
+			t.Errorf("%s = %q, want %q", name, got, value)
+		}
+	}
+	if !strings.Contains(query.Get("scope"), "openid") {
+		t.Errorf("scope %q does not request openid", query.Get("scope"))
+	}
+}
+
+func TestDiscoveryRejectsIssuerMismatch(t *testing.T) {
+	mock := newMockProvider(t)
+	mock.issuer = "https://evil.example.com"
+
+	_, err := mock.provider().AuthCodeURL(context.Background(), testRedirectURL, "state", "nonce", "verifier")
+	if err == nil || !strings.Contains(err.Error(), "issuer") {
+		t.Fatalf("expected an issuer mismatch error, got %v", err)
+	}
+}
+
+func TestExchange(t *testing.T) {
+	mock := newMockProvider(t)
+	p := mock.provider()
+	mock.authorize(p, "state", "nonce", "verifier")
+
+	claims, err := p.Exchange(context.Background(), testRedirectURL, testCode, "verifier", "nonce")
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+
+	want := Claims{Subject: "user-42", Email: "ada@example.com", EmailVerified: true, Name: "Ada Lovelace"}
+	if *claims != want {
+		t.Errorf("claims = %+v, want %+v", *claims, want)
+	}
+}
+
+func TestExchangeRejectsWrongVerifier(t *testing.T) {
+	mock := newMockProvider(t)
+	p := mock.provider()
+	mock.authorize(p, "state", "nonce", "verifier")
+
+	_, err := p.Exchange(context.Background(), testRedirectURL, testCode, "another-verifier", "nonce")
+	if err == nil || !strings.Contains(err.Error(), "invalid_grant") {
+		t.Fatalf("expected the token request to fail, got %v", err)
+	}
+}
+
+func TestExchangeRejectsNonceMismatch(t *testing.T) {
+	mock := newMockProvider(t)
+	p := mock.provider()
+	mock.authorize(p, "state", "nonce", "verifier")
+
+	_, err := p.Exchange(context.Background(), testRedirectURL, testCode, "verifier", "replayed-nonce")
+	if !errors.Is(err, ErrInvalidIDToken) || !strings.Contains(err.Error(), "nonce") {
+		t.Fatalf("expected a nonce mismatch, got %v", err)
+	}
+}
+
+func TestExchangeRejectsUnknownKeyAndLimitsRefetch(t *testing.T) {
+	mock := newMockProvider(t)
+	p := mock.provider()
+	mock.authorize(p, "state", "nonce", "verifier")
+
+	// Tokens signed with a key the provider never published
+	mock.signKey = "forged"
+	for i := 0; i < 3; i++ {
+		if _, err := p.Exchange(context.Background(), testRedirectURL, testCode, "verifier", "nonce"); !errors.Is(err, ErrInvalidIDToken) {
+			t.Fatalf("attempt %d: expected an invalid ID token, got %v", i+1, err)
+		}
+	}
+	if hits := mock.jwksRequests(); hits != 1 {
+		t.Fatalf("signing keys fetched %d times, want 1", hits)
+	}
+}
+
+func TestExchangePicksUpRotatedKey(t *testing.T) {
+	mock := newMockProvider(t)
+	p := mock.provider()
+	mock.authorize(p, "state", "nonce", "verifier")
+
+	if _, err := p.Exchange(context.Background(), testRedirectURL, testCode, "verifier", "nonce"); err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+
+	// The provider rotates to a new key once the refetch interval has passed
+	mock.mu.Lock()
+	mock.keys["key-2"] = generateKey(t)
+	mock.signKey = "key-2"
+	mock.mu.Unlock()
+	p.mu.Lock()
+	p.keysFetched = time.Now().Add(-keyRefetchInterval)
+	p.mu.Unlock()
+
+	if _, err := p.Exchange(context.Background(), testRedirectURL, testCode, "verifier", "nonce"); err != nil {
+		t.Fatalf("Exchange with rotated key: %v", err)
+	}
+	if hits := mock.jwksRequests(); hits != 2 {
+		t.Fatalf("signing keys fetched %d times, want 2", hits)
+	}
+}
+
+func TestSigningKeyFetchesOnceForConcurrentRequests(t *testing.T) {
+	mock := newMockProvider(t)
+	p := mock.provider()
+	meta, err := p.discover(context.Background())
+	if err != nil {
+		t.Fatalf("discover: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := p.signingKey(context.Background(), meta, "key-1"); err != nil {
+				t.Errorf("signingKey: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if hits := mock.jwksRequests(); hits != 1 {
+		t.Fatalf("signing keys fetched %d times, want 1", hits)
+	}
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"github.com/icl00ud/goban/internal/models"
+	"gorm.io/gorm"
+)
+
+type ActivityRepository struct {
+	db *gorm.DB
+}
+
+func NewActivityRepository(db *gorm.DB) *ActivityRepository {
+	return &ActivityRepository{db: db}
+}
+
+// Create records an activity
+func (r *ActivityRepository) Create(activity *models.Activity) error {
+	return r.db.Create(activity).Error
+}
+
+// FindPageByBoardID finds up to limit activities of a board, newest first,
+// older than the cursor activity ID when it is not zero
+func (r *ActivityRepository) FindPageByBoardID(boardID, cursor uint, limit int) ([]models.Activity, error) {
+	return r.findPage(r.db.Where("board_id = ?", boardID), cursor, limit)
+}
+
+// FindPageByEntity finds up to limit activities of an entity, newest first,
+// older than the cursor activity ID when it is not zero
+func (r *ActivityRepository) FindPageByEntity(entityType string, entityID, cursor uint, limit int) ([]models.Activity, error) {
+	return r.findPage(r.db.Where("entity_type = ? AND entity_id = ?", entityType, entityID), cursor, limit)
+}
+
+func (r *ActivityRepository) findPage(query *gorm.DB, cursor uint, limit int) ([]models.Activity, error) {
+	if cursor > 0 {
+		query = query.Where("id < ?", cursor)
+	}
+
+	var activities []models.Activity
+	err := query.Preload("User", withDeletedUsers).Order("id DESC").Limit(limit).Find(&activities).Error
+	return activities, err
+}
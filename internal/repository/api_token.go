@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/icl00ud/goban/internal/models"
+	"gorm.io/gorm"
+)
+
+type APITokenRepository struct {
+	db *gorm.DB
+}
+
+func NewAPITokenRepository(db *gorm.DB) *APITokenRepository {
+	return &APITokenRepository{db: db}
+}
+
+// Create creates a new API token
+func (r *APITokenRepository) Create(token *models.APIToken) error {
+	return r.db.Create(token).Error
+}
+
+// FindByID finds an API token by ID
+func (r *APITokenRepository) FindByID(id uint) (*models.APIToken, error) {
+	var token models.APIToken
+	err := r.db.First(&token, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// FindByIDWithUser finds an API token by ID, with its user
+func (r *APITokenRepository) FindByIDWithUser(id uint) (*models.APIToken, error) {
+	var token models.APIToken
+	err := r.db.Preload("User").First(&token, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// FindByHash finds an API token by the hash of its secret, with its user
+func (r *APITokenRepository) FindByHash(hash string) (*models.APIToken, error) {
+	var token models.APIToken
+	err := r.db.Preload("User").Where("token_hash = ?", hash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// FindAllByUserID finds all API tokens of a user, newest first
+func (r *APITokenRepository) FindAllByUserID(userID uint) ([]models.APIToken, error) {
+	var tokens []models.APIToken
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC, id DESC").Find(&tokens).Error
+	return tokens, err
+}
+
+// TouchLastUsed records when an API token was last used
+func (r *APITokenRepository) TouchLastUsed(id uint, usedAt time.Time) error {
+	return r.db.Model(&models.APIToken{}).Where("id = ?", id).Update("last_used_at", usedAt).Error
+}
+
+// Delete permanently deletes an API token
+func (r *APITokenRepository) Delete(id uint) error {
+	return r.db.Delete(&models.APIToken{}, id).Error
+}
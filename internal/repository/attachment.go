@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"github.com/icl00ud/goban/internal/models"
+	"gorm.io/gorm"
+)
+
+type AttachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) *AttachmentRepository {
+	return &AttachmentRepository{db: db}
+}
+
+// Create creates a new attachment
+func (r *AttachmentRepository) Create(attachment *models.Attachment) error {
+	return r.db.Create(attachment).Error
+}
+
+// FindByID finds an attachment by ID with its uploader
+func (r *AttachmentRepository) FindByID(id uint) (*models.Attachment, error) {
+	var attachment models.Attachment
+	err := r.db.Preload("User", withDeletedUsers).First(&attachment, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// FindAllByCardID finds all attachments of a card, oldest first
+func (r *AttachmentRepository) FindAllByCardID(cardID uint) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	err := r.db.Preload("User", withDeletedUsers).Where("card_id = ?", cardID).Order("created_at ASC, id ASC").Find(&attachments).Error
+	return attachments, err
+}
+
+// Delete deletes an attachment
+func (r *AttachmentRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Attachment{}, id).Error
+}
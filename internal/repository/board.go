@@ -28,14 +28,34 @@ func (r *BoardRepository) FindByID(id uint) (*models.Board, error) {
 	return &board, nil
 }
 
-// FindByIDWithDetails finds a board with its columns and cards
+// CardFilter narrows the cards loaded with a board
+type CardFilter struct {
+	// LabelIDs keeps only cards that carry at least one of these labels
+	LabelIDs []uint
+}
+
+// FindByIDWithDetails finds a board with its columns, cards and swimlanes
 func (r *BoardRepository) FindByIDWithDetails(id uint) (*models.Board, error) {
+	return r.FindByIDWithFilteredDetails(id, CardFilter{})
+}
+
+// FindByIDWithFilteredDetails finds a board with its columns, swimlanes and
+// the cards matching the filter
+func (r *BoardRepository) FindByIDWithFilteredDetails(id uint, filter CardFilter) (*models.Board, error) {
 	var board models.Board
 	err := r.db.
 		Preload("Columns", func(db *gorm.DB) *gorm.DB {
 			return db.Order("position ASC")
 		}).
 		Preload("Columns.Cards", func(db *gorm.DB) *gorm.DB {
+			if len(filter.LabelIDs) > 0 {
+				db = db.Where("id IN (?)", r.db.Table("card_labels").Select("card_id").Where("label_id IN ?", filter.LabelIDs))
+			}
+			return db.Scopes(withCardCounts).Order("position ASC")
+		}).
+		Preload("Columns.Cards.Assignees").
+		Preload("Columns.Cards.Labels").
+		Preload("Swimlanes", func(db *gorm.DB) *gorm.DB {
 			return db.Order("position ASC")
 		}).
 		First(&board, id).Error
@@ -45,39 +65,56 @@ func (r *BoardRepository) FindByIDWithDetails(id uint) (*models.Board, error) {
 	return &board, nil
 }
 
-// FindAllByUserID finds all boards for a user ordered by position
+// FindAllByUserID finds all boards a user owns or is a member of, ordered by position
 func (r *BoardRepository) FindAllByUserID(userID uint) ([]models.Board, error) {
 	var boards []models.Board
-	err := r.db.Where("user_id = ?", userID).Order("position ASC, created_at DESC").Find(&boards).Error
+	err := r.db.Scopes(accessibleBy(userID), orderedFor(userID)).Find(&boards).Error
 	return boards, err
 }
 
-// FindAllByUserIDWithDetails finds all boards for a user with columns and cards
+// FindIDsOwnedBy finds the IDs of the boards a user created
+func (r *BoardRepository) FindIDsOwnedBy(userID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&models.Board{}).Where("user_id = ?", userID).Pluck("id", &ids).Error
+	return ids, err
+}
+
+// FindAllByUserIDWithDetails finds all boards a user can access with columns and cards
 func (r *BoardRepository) FindAllByUserIDWithDetails(userID uint) ([]models.Board, error) {
 	var boards []models.Board
 	err := r.db.
-		Where("user_id = ?", userID).
+		Scopes(accessibleBy(userID), orderedFor(userID)).
 		Preload("Columns", func(db *gorm.DB) *gorm.DB {
 			return db.Order("position ASC")
 		}).
 		Preload("Columns.Cards", func(db *gorm.DB) *gorm.DB {
-			return db.Order("position ASC")
+			return db.Scopes(withCardCounts).Order("position ASC")
 		}).
-		Order("position ASC, created_at DESC").
+		Preload("Columns.Cards.Assignees").
+		Preload("Columns.Cards.Labels").
 		Find(&boards).Error
 	return boards, err
 }
 
-// GetMaxPosition returns the highest position for a user's boards
+// GetMaxPosition returns the highest position among the boards a user
+// arranges on their dashboard
 func (r *BoardRepository) GetMaxPosition(userID uint) int {
 	var maxPos int
-	r.db.Model(&models.Board{}).Where("user_id = ?", userID).Select("COALESCE(MAX(position), -1)").Scan(&maxPos)
+	r.db.Model(&models.Board{}).
+		Scopes(accessibleBy(userID), withPositionFor(userID)).
+		Select("COALESCE(MAX(COALESCE(own_membership.position, boards.position)), -1)").
+		Scan(&maxPos)
 	return maxPos
 }
 
-// UpdatePositions updates positions for multiple boards in a transaction
-func (r *BoardRepository) UpdatePositions(userID uint, boardIDs []uint) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+// UpdatePositions stores the order a user arranged their boards in, in a
+// transaction. Boards the user created keep their position on the board;
+// shared boards keep it on the user's membership. It returns the IDs of the
+// boards the user created.
+func (r *BoardRepository) UpdatePositions(userID uint, boardIDs []uint) ([]uint, error) {
+	var owned []uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		owned = nil
 		for i, boardID := range boardIDs {
 			result := tx.Model(&models.Board{}).
 				Where("id = ? AND user_id = ?", boardID, userID).
@@ -85,9 +122,24 @@ func (r *BoardRepository) UpdatePositions(userID uint, boardIDs []uint) error {
 			if result.Error != nil {
 				return result.Error
 			}
+			if result.RowsAffected > 0 {
+				owned = append(owned, boardID)
+				continue
+			}
+
+			result = tx.Model(&models.BoardMember{}).
+				Where("board_id = ? AND user_id = ?", boardID, userID).
+				Update("position", i)
+			if result.Error != nil {
+				return result.Error
+			}
 		}
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	return owned, nil
 }
 
 // Update updates a board
@@ -97,12 +149,74 @@ func (r *BoardRepository) Update(board *models.Board) error {
 
 // Delete soft deletes a board
 func (r *BoardRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Board{}, id).Error
+	// Columns and cards share the board's deletion time so restoring the
+	// board brings back exactly what was deleted with it
+	now := deletionTime()
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		columnIDs := tx.Unscoped().Model(&models.Column{}).Select("id").Where("board_id = ?", id)
+		if err := tx.Model(&models.Card{}).Where("column_id IN (?)", columnIDs).Update("deleted_at", now).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Column{}).Where("board_id = ?", id).Update("deleted_at", now).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Board{}).Where("id = ?", id).Update("deleted_at", now).Error
+	})
+}
+
+// GetUserRole returns the user's role on a board, or an empty string if the
+// user has no access. The board creator is always treated as an owner.
+func (r *BoardRepository) GetUserRole(boardID, userID uint) string {
+	return r.userRole(r.db, boardID, userID)
+}
+
+// GetUserRoleIncludingDeleted returns the user's role on a board even when the
+// board has been deleted
+func (r *BoardRepository) GetUserRoleIncludingDeleted(boardID, userID uint) string {
+	return r.userRole(r.db.Unscoped(), boardID, userID)
 }
 
-// BelongsToUser checks if a board belongs to a user
-func (r *BoardRepository) BelongsToUser(boardID, userID uint) bool {
-	var count int64
-	r.db.Model(&models.Board{}).Where("id = ? AND user_id = ?", boardID, userID).Count(&count)
-	return count > 0
+func (r *BoardRepository) userRole(db *gorm.DB, boardID, userID uint) string {
+	var board models.Board
+	if err := db.Select("id", "user_id").First(&board, boardID).Error; err != nil {
+		return ""
+	}
+	if board.UserID == userID {
+		return models.RoleOwner
+	}
+
+	var member models.BoardMember
+	err := r.db.Where("board_id = ? AND user_id = ?", boardID, userID).First(&member).Error
+	if err != nil {
+		return ""
+	}
+	return member.Role
+}
+
+// accessibleBy limits a board query to boards the user owns or is a member of
+// withPositionFor joins the user's membership of each board, whose position
+// orders shared boards on the user's dashboard
+func withPositionFor(userID uint) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Joins("LEFT JOIN board_members AS own_membership ON own_membership.board_id = boards.id AND own_membership.user_id = ?", userID)
+	}
+}
+
+// orderedFor sorts boards in the order the user arranged them
+func orderedFor(userID uint) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Scopes(withPositionFor(userID)).
+			Select("boards.*").
+			Order("COALESCE(own_membership.position, boards.position) ASC, boards.created_at DESC")
+	}
+}
+
+func accessibleBy(userID uint) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(
+			"boards.user_id = ? OR boards.id IN (?)",
+			userID,
+			db.Session(&gorm.Session{NewDB: true}).Model(&models.BoardMember{}).Select("board_id").Where("user_id = ?", userID),
+		)
+	}
 }
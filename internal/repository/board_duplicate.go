@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"github.com/icl00ud/goban/internal/models"
+	"gorm.io/gorm"
+)
+
+// DuplicateOptions selects what is copied along with a board's columns and
+// swimlanes
+type DuplicateOptions struct {
+	Cards      bool
+	Labels     bool
+	Checklists bool
+}
+
+// Duplicate creates board as a copy of the source board's columns, swimlanes
+// and the selected contents in a transaction, keeping every position
+func (r *BoardRepository) Duplicate(sourceID uint, board *models.Board, opts DuplicateOptions) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(board).Error; err != nil {
+			return err
+		}
+
+		labels := make(map[uint]models.Label)
+		if opts.Labels {
+			var sourceLabels []models.Label
+			if err := tx.Where("board_id = ?", sourceID).Find(&sourceLabels).Error; err != nil {
+				return err
+			}
+			for _, source := range sourceLabels {
+				label := models.Label{Name: source.Name, Color: source.Color, BoardID: board.ID}
+				if err := tx.Create(&label).Error; err != nil {
+					return err
+				}
+				labels[source.ID] = label
+			}
+		}
+
+		var sourceSwimlanes []models.Swimlane
+		if err := tx.Where("board_id = ?", sourceID).Find(&sourceSwimlanes).Error; err != nil {
+			return err
+		}
+		swimlanes := make(map[uint]uint, len(sourceSwimlanes))
+		for _, source := range sourceSwimlanes {
+			swimlane := models.Swimlane{Title: source.Title, Position: source.Position, Collapsed: source.Collapsed, BoardID: board.ID}
+			if err := tx.Create(&swimlane).Error; err != nil {
+				return err
+			}
+			swimlanes[source.ID] = swimlane.ID
+		}
+
+		var sourceColumns []models.Column
+		if err := tx.Where("board_id = ?", sourceID).Find(&sourceColumns).Error; err != nil {
+			return err
+		}
+		for _, source := range sourceColumns {
+			column := models.Column{Title: source.Title, Position: source.Position, BoardID: board.ID, WIPLimit: source.WIPLimit}
+			if err := tx.Create(&column).Error; err != nil {
+				return err
+			}
+
+			if opts.Cards {
+				if err := duplicateCards(tx, source.ID, column.ID, labels, swimlanes, opts); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// duplicateCards copies the cards of a column into another column, with the
+// copied labels and swimlanes mapped by source ID
+func duplicateCards(tx *gorm.DB, sourceColumnID, columnID uint, labels map[uint]models.Label, swimlanes map[uint]uint, opts DuplicateOptions) error {
+	var sourceCards []models.Card
+	if err := tx.Preload("Labels").Where("column_id = ?", sourceColumnID).Find(&sourceCards).Error; err != nil {
+		return err
+	}
+
+	for _, source := range sourceCards {
+		card := models.Card{
+			Title:       source.Title,
+			Description: source.Description,
+			Priority:    source.Priority,
+			Position:    source.Position,
+			ColumnID:    columnID,
+			StartAt:     source.StartAt,
+			DueAt:       source.DueAt,
+		}
+		if source.SwimlaneID != nil {
+			if id, ok := swimlanes[*source.SwimlaneID]; ok {
+				card.SwimlaneID = &id
+			}
+		}
+		for _, sourceLabel := range source.Labels {
+			if label, ok := labels[sourceLabel.ID]; ok {
+				card.Labels = append(card.Labels, label)
+			}
+		}
+		if err := tx.Create(&card).Error; err != nil {
+			return err
+		}
+
+		if !opts.Checklists {
+			continue
+		}
+
+		var sourceChecklists []models.Checklist
+		if err := tx.Preload("Items").Where("card_id = ?", source.ID).Find(&sourceChecklists).Error; err != nil {
+			return err
+		}
+		for _, sourceChecklist := range sourceChecklists {
+			checklist := models.Checklist{Title: sourceChecklist.Title, Position: sourceChecklist.Position, CardID: card.ID}
+			for _, item := range sourceChecklist.Items {
+				checklist.Items = append(checklist.Items, models.ChecklistItem{Text: item.Text, Done: item.Done, Position: item.Position})
+			}
+			if err := tx.Create(&checklist).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
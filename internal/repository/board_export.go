@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/icl00ud/goban/internal/models"
+	"gorm.io/gorm"
+)
+
+// CardRow is a card flattened with its column and swimlane, as streamed for
+// board exports
+type CardRow struct {
+	ID             uint
+	ColumnID       uint
+	ColumnTitle    string
+	ColumnPosition int
+	Title          string
+	Description    string
+	Position       int
+	Priority       string
+	SwimlaneTitle  string
+	StartAt        *time.Time
+	DueAt          *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// FindByIDWithColumns finds a board with its columns but none of their cards
+func (r *BoardRepository) FindByIDWithColumns(id uint) (*models.Board, error) {
+	var board models.Board
+	err := r.db.
+		Preload("Columns", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC, id ASC")
+		}).
+		First(&board, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
+
+// EachCardRow calls fn with every live card of a board in column and card
+// order. Rows are read one at a time so large boards are never held in
+// memory; iteration stops at the first error fn returns.
+func (r *BoardRepository) EachCardRow(boardID uint, fn func(row *CardRow) error) error {
+	rows, err := r.db.Table("cards").
+		Select("cards.id, cards.column_id, columns.title AS column_title, columns.position AS column_position, "+
+			"cards.title, cards.description, cards.position, cards.priority, COALESCE(swimlanes.title, '') AS swimlane_title, "+
+			"cards.start_at, cards.due_at, cards.created_at, cards.updated_at").
+		Joins("JOIN columns ON columns.id = cards.column_id").
+		Joins("LEFT JOIN swimlanes ON swimlanes.id = cards.swimlane_id").
+		Where("columns.board_id = ? AND cards.deleted_at IS NULL AND columns.deleted_at IS NULL", boardID).
+		Order("columns.position ASC, columns.id ASC, cards.position ASC, cards.id ASC").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row CardRow
+		if err := r.db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
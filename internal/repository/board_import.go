@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"github.com/icl00ud/goban/internal/models"
+	"gorm.io/gorm"
+)
+
+// BoardImport is a board with everything on it, read from an export
+// document. Label and swimlane IDs are the document's own and are remapped
+// to the IDs of the created rows.
+type BoardImport struct {
+	Board     *models.Board
+	Labels    []models.Label
+	Swimlanes []models.Swimlane
+	Columns   []ColumnImport
+}
+
+// ColumnImport is a column of an imported board with its cards
+type ColumnImport struct {
+	Column models.Column
+	Cards  []CardImport
+}
+
+// CardImport is a card of an imported board. The card's SwimlaneID and
+// LabelIDs refer to the document's swimlanes and labels.
+type CardImport struct {
+	Card        models.Card
+	LabelIDs    []uint
+	AssigneeIDs []uint
+	Checklists  []models.Checklist
+	Comments    []models.Comment
+}
+
+// Import creates an imported board and everything on it in a transaction.
+// Positions follow the order of the imported slices.
+func (r *BoardRepository) Import(data *BoardImport) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(data.Board).Error; err != nil {
+			return err
+		}
+		boardID := data.Board.ID
+
+		labels := make(map[uint]models.Label, len(data.Labels))
+		for _, source := range data.Labels {
+			label := models.Label{Name: source.Name, Color: source.Color, BoardID: boardID}
+			if err := tx.Create(&label).Error; err != nil {
+				return err
+			}
+			labels[source.ID] = label
+		}
+
+		swimlanes := make(map[uint]uint, len(data.Swimlanes))
+		for i, source := range data.Swimlanes {
+			swimlane := models.Swimlane{Title: source.Title, Position: i, Collapsed: source.Collapsed, BoardID: boardID}
+			if err := tx.Create(&swimlane).Error; err != nil {
+				return err
+			}
+			swimlanes[source.ID] = swimlane.ID
+		}
+
+		for i, ci := range data.Columns {
+			column := ci.Column
+			column.Position = i
+			column.BoardID = boardID
+			if err := tx.Create(&column).Error; err != nil {
+				return err
+			}
+
+			for j, ca := range ci.Cards {
+				if err := importCard(tx, ca, column.ID, j, labels, swimlanes); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// importCard creates an imported card with its labels, assignees, checklists
+// and comments
+func importCard(tx *gorm.DB, data CardImport, columnID uint, position int, labels map[uint]models.Label, swimlanes map[uint]uint) error {
+	card := data.Card
+	card.ColumnID = columnID
+	card.Position = position
+	if card.SwimlaneID != nil {
+		id := swimlanes[*card.SwimlaneID]
+		card.SwimlaneID = &id
+	}
+	for _, id := range data.LabelIDs {
+		card.Labels = append(card.Labels, labels[id])
+	}
+	if len(data.AssigneeIDs) > 0 {
+		if err := tx.Where("id IN ?", data.AssigneeIDs).Find(&card.Assignees).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Create(&card).Error; err != nil {
+		return err
+	}
+
+	for i, checklist := range data.Checklists {
+		checklist.Position = i
+		checklist.CardID = card.ID
+		for j := range checklist.Items {
+			checklist.Items[j].Position = j
+		}
+		if err := tx.Create(&checklist).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, comment := range data.Comments {
+		comment.CardID = card.ID
+		if err := tx.Create(&comment).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
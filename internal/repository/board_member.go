@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"github.com/icl00ud/goban/internal/models"
+	"gorm.io/gorm"
+)
+
+type BoardMemberRepository struct {
+	db *gorm.DB
+}
+
+func NewBoardMemberRepository(db *gorm.DB) *BoardMemberRepository {
+	return &BoardMemberRepository{db: db}
+}
+
+// Create adds a member to a board
+func (r *BoardMemberRepository) Create(member *models.BoardMember) error {
+	return r.db.Create(member).Error
+}
+
+// FindByBoardAndUser finds a membership by board and user
+func (r *BoardMemberRepository) FindByBoardAndUser(boardID, userID uint) (*models.BoardMember, error) {
+	var member models.BoardMember
+	err := r.db.Preload("User").Where("board_id = ? AND user_id = ?", boardID, userID).First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// FindAllByBoardID finds all members of a board with their users
+func (r *BoardMemberRepository) FindAllByBoardID(boardID uint) ([]models.BoardMember, error) {
+	var members []models.BoardMember
+	err := r.db.Preload("User").Where("board_id = ?", boardID).Order("created_at ASC").Find(&members).Error
+	return members, err
+}
+
+// Update updates a membership
+func (r *BoardMemberRepository) Update(member *models.BoardMember) error {
+	return r.db.Save(member).Error
+}
+
+// Delete removes a member from a board
+func (r *BoardMemberRepository) Delete(boardID, userID uint) error {
+	return r.db.Where("board_id = ? AND user_id = ?", boardID, userID).Delete(&models.BoardMember{}).Error
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/icl00ud/goban/internal/models"
+)
+
+func TestBoardPositionsArePerUser(t *testing.T) {
+	db := newTestDB(t)
+	boardRepo := NewBoardRepository(db)
+
+	alice := &models.User{Email: "alice@example.com", PasswordHash: "x", Name: "Alice"}
+	bob := &models.User{Email: "bob@example.com", PasswordHash: "x", Name: "Bob"}
+	mustCreate(t, db, alice)
+	mustCreate(t, db, bob)
+
+	own1 := &models.Board{Name: "Alice 1", UserID: alice.ID, Position: 0}
+	own2 := &models.Board{Name: "Alice 2", UserID: alice.ID, Position: 1}
+	shared := &models.Board{Name: "Bob's", UserID: bob.ID, Position: 0}
+	bobs := &models.Board{Name: "Bob 2", UserID: bob.ID, Position: 1}
+	for _, board := range []*models.Board{own1, own2, shared, bobs} {
+		mustCreate(t, db, board)
+	}
+	mustCreate(t, db, &models.BoardMember{BoardID: shared.ID, UserID: alice.ID, Role: models.RoleViewer})
+
+	owned, err := boardRepo.UpdatePositions(alice.ID, []uint{own2.ID, shared.ID, own1.ID})
+	if err != nil {
+		t.Fatalf("failed to update positions: %v", err)
+	}
+	if len(owned) != 2 || owned[0] != own2.ID || owned[1] != own1.ID {
+		t.Errorf("owned = %v, want [%d %d]", owned, own2.ID, own1.ID)
+	}
+
+	assertOrder := func(userID uint, want ...uint) {
+		t.Helper()
+		boards, err := boardRepo.FindAllByUserID(userID)
+		if err != nil {
+			t.Fatalf("failed to list boards: %v", err)
+		}
+		var got []uint
+		for _, board := range boards {
+			got = append(got, board.ID)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("boards = %v, want %v", got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("boards = %v, want %v", got, want)
+			}
+		}
+	}
+
+	assertOrder(alice.ID, own2.ID, shared.ID, own1.ID)
+	// Alice's ordering does not move the shared board for its owner
+	assertOrder(bob.ID, shared.ID, bobs.ID)
+
+	if got := boardRepo.GetMaxPosition(alice.ID); got != 2 {
+		t.Errorf("max position = %d, want 2", got)
+	}
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/icl00ud/goban/internal/models"
 	"gorm.io/gorm"
 )
@@ -28,6 +30,64 @@ func (r *CardRepository) FindByID(id uint) (*models.Card, error) {
 	return &card, nil
 }
 
+// FindByIDWithDetails finds a card with its assignees, labels and counters
+func (r *CardRepository) FindByIDWithDetails(id uint) (*models.Card, error) {
+	var card models.Card
+	err := r.db.Scopes(withCardCounts).Preload("Assignees").Preload("Labels").First(&card, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// FindAllAssignedToUser finds all cards assigned to a user on boards the user
+// can access, with their column and board, ordered by board, column and position
+func (r *CardRepository) FindAllAssignedToUser(userID uint) ([]models.Card, error) {
+	var cards []models.Card
+	err := r.db.
+		Scopes(withBoardContext(userID)).
+		Joins("JOIN card_assignees ON card_assignees.card_id = cards.id").
+		Where("card_assignees.user_id = ?", userID).
+		Order("cards.position ASC").
+		Find(&cards).Error
+	return cards, err
+}
+
+// FindAllDueBetween finds all cards with a due date in the given range on
+// boards the user can access, ordered by board, column and due date. A nil
+// bound leaves that side of the range open.
+func (r *CardRepository) FindAllDueBetween(userID uint, from, to *time.Time) ([]models.Card, error) {
+	query := r.db.
+		Scopes(withBoardContext(userID)).
+		Where("cards.due_at IS NOT NULL")
+	if from != nil {
+		query = query.Where("cards.due_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("cards.due_at < ?", *to)
+	}
+
+	var cards []models.Card
+	err := query.Order("cards.due_at ASC").Find(&cards).Error
+	return cards, err
+}
+
+// withBoardContext joins cards to their column and board, limits them to
+// boards the user can access and orders them so cards of the same board and
+// column are adjacent
+func withBoardContext(userID uint) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.
+			Joins("JOIN columns ON columns.id = cards.column_id AND columns.deleted_at IS NULL").
+			Joins("JOIN boards ON boards.id = columns.board_id AND boards.deleted_at IS NULL").
+			Scopes(accessibleBy(userID), withCardCounts).
+			Preload("Column.Board").
+			Preload("Assignees").
+			Preload("Labels").
+			Order("boards.position ASC, boards.created_at DESC, boards.id ASC, columns.position ASC, columns.id ASC")
+	}
+}
+
 // FindAllByColumnID finds all cards for a column
 func (r *CardRepository) FindAllByColumnID(columnID uint) ([]models.Card, error) {
 	var cards []models.Card
@@ -52,8 +112,15 @@ func (r *CardRepository) GetMaxPosition(columnID uint) int {
 	return maxPos
 }
 
-// MoveCard moves a card to a new column and position
-func (r *CardRepository) MoveCard(cardID, targetColumnID uint, position int) error {
+// CountByColumn counts the cards in a column
+func (r *CardRepository) CountByColumn(columnID uint) int {
+	var count int64
+	r.db.Model(&models.Card{}).Where("column_id = ?", columnID).Count(&count)
+	return int(count)
+}
+
+// MoveCard moves a card to a new column, swimlane and position
+func (r *CardRepository) MoveCard(cardID, targetColumnID uint, swimlaneID *uint, position int) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		// Shift cards at and after the target position in the target column
 		if err := tx.Model(&models.Card{}).
@@ -62,12 +129,13 @@ func (r *CardRepository) MoveCard(cardID, targetColumnID uint, position int) err
 			return err
 		}
 
-		// Update the card's column and position
+		// Update the card's column, swimlane and position
 		if err := tx.Model(&models.Card{}).
 			Where("id = ?", cardID).
 			Updates(map[string]interface{}{
-				"column_id": targetColumnID,
-				"position":  position,
+				"column_id":   targetColumnID,
+				"swimlane_id": swimlaneID,
+				"position":    position,
 			}).Error; err != nil {
 			return err
 		}
@@ -76,11 +144,11 @@ func (r *CardRepository) MoveCard(cardID, targetColumnID uint, position int) err
 	})
 }
 
-// UpdatePositions updates positions for multiple cards in a transaction
-func (r *CardRepository) UpdatePositions(cardIDs []uint, positions []int) error {
+// UpdatePositions updates positions for multiple cards of a column in a transaction
+func (r *CardRepository) UpdatePositions(columnID uint, cardIDs []uint, positions []int) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		for i, id := range cardIDs {
-			if err := tx.Model(&models.Card{}).Where("id = ?", id).Update("position", positions[i]).Error; err != nil {
+			if err := tx.Model(&models.Card{}).Where("id = ? AND column_id = ?", id, columnID).Update("position", positions[i]).Error; err != nil {
 				return err
 			}
 		}
@@ -97,3 +165,40 @@ func (r *CardRepository) GetColumnBoardID(cardID uint) (uint, error) {
 	}
 	return card.Column.BoardID, nil
 }
+
+// GetBoardIDIncludingDeleted returns the board ID of a card even when the
+// card or its column has been deleted
+func (r *CardRepository) GetBoardIDIncludingDeleted(cardID uint) (uint, error) {
+	var boardIDs []uint
+	err := r.db.Table("cards").
+		Joins("JOIN columns ON columns.id = cards.column_id").
+		Where("cards.id = ?", cardID).
+		Pluck("columns.board_id", &boardIDs).Error
+	if err != nil {
+		return 0, err
+	}
+	if len(boardIDs) == 0 {
+		return 0, gorm.ErrRecordNotFound
+	}
+	return boardIDs[0], nil
+}
+
+// withCardCounts selects the computed per-card counters alongside the card columns
+func withCardCounts(db *gorm.DB) *gorm.DB {
+	return db.Select("cards.*, " +
+		"(SELECT COUNT(*) FROM comments WHERE comments.card_id = cards.id AND comments.deleted_at IS NULL) AS comment_count, " +
+		"(SELECT COUNT(*) FROM checklist_items JOIN checklists ON checklists.id = checklist_items.checklist_id " +
+		"WHERE checklists.card_id = cards.id AND checklist_items.done) AS checklist_done, " +
+		"(SELECT COUNT(*) FROM checklist_items JOIN checklists ON checklists.id = checklist_items.checklist_id " +
+		"WHERE checklists.card_id = cards.id) AS checklist_total")
+}
+
+// AddAssignee assigns a user to a card
+func (r *CardRepository) AddAssignee(card *models.Card, user *models.User) error {
+	return r.db.Model(card).Association("Assignees").Append(user)
+}
+
+// RemoveAssignee unassigns a user from a card
+func (r *CardRepository) RemoveAssignee(card *models.Card, user *models.User) error {
+	return r.db.Model(card).Association("Assignees").Delete(user)
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"github.com/icl00ud/goban/internal/models"
+	"gorm.io/gorm"
+)
+
+type ChecklistRepository struct {
+	db *gorm.DB
+}
+
+func NewChecklistRepository(db *gorm.DB) *ChecklistRepository {
+	return &ChecklistRepository{db: db}
+}
+
+// Create creates a new checklist
+func (r *ChecklistRepository) Create(checklist *models.Checklist) error {
+	return r.db.Create(checklist).Error
+}
+
+// FindByID finds a checklist with its items
+func (r *ChecklistRepository) FindByID(id uint) (*models.Checklist, error) {
+	var checklist models.Checklist
+	err := r.db.
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		First(&checklist, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &checklist, nil
+}
+
+// FindAllByCardID finds all checklists of a card with their items
+func (r *ChecklistRepository) FindAllByCardID(cardID uint) ([]models.Checklist, error) {
+	var checklists []models.Checklist
+	err := r.db.
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Where("card_id = ?", cardID).
+		Order("position ASC").
+		Find(&checklists).Error
+	return checklists, err
+}
+
+// Update updates a checklist
+func (r *ChecklistRepository) Update(checklist *models.Checklist) error {
+	return r.db.Omit("Items").Save(checklist).Error
+}
+
+// Delete deletes a checklist and its items
+func (r *ChecklistRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("checklist_id = ?", id).Delete(&models.ChecklistItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Checklist{}, id).Error
+	})
+}
+
+// GetMaxPosition returns the maximum position value for checklists in a card
+func (r *ChecklistRepository) GetMaxPosition(cardID uint) int {
+	var maxPos int
+	r.db.Model(&models.Checklist{}).Where("card_id = ?", cardID).Select("COALESCE(MAX(position), -1)").Scan(&maxPos)
+	return maxPos
+}
+
+// UpdatePositions updates positions for multiple checklists of a card in a transaction
+func (r *ChecklistRepository) UpdatePositions(cardID uint, checklistIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range checklistIDs {
+			if err := tx.Model(&models.Checklist{}).Where("id = ? AND card_id = ?", id, cardID).Update("position", i).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CreateItem creates a new checklist item
+func (r *ChecklistRepository) CreateItem(item *models.ChecklistItem) error {
+	return r.db.Create(item).Error
+}
+
+// FindItemByID finds a checklist item by ID
+func (r *ChecklistRepository) FindItemByID(id uint) (*models.ChecklistItem, error) {
+	var item models.ChecklistItem
+	err := r.db.First(&item, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// UpdateItem updates a checklist item
+func (r *ChecklistRepository) UpdateItem(item *models.ChecklistItem) error {
+	return r.db.Save(item).Error
+}
+
+// DeleteItem deletes a checklist item
+func (r *ChecklistRepository) DeleteItem(id uint) error {
+	return r.db.Delete(&models.ChecklistItem{}, id).Error
+}
+
+// GetMaxItemPosition returns the maximum position value for items in a checklist
+func (r *ChecklistRepository) GetMaxItemPosition(checklistID uint) int {
+	var maxPos int
+	r.db.Model(&models.ChecklistItem{}).Where("checklist_id = ?", checklistID).Select("COALESCE(MAX(position), -1)").Scan(&maxPos)
+	return maxPos
+}
+
+// UpdateItemPositions updates positions for multiple items of a checklist in a transaction
+func (r *ChecklistRepository) UpdateItemPositions(checklistID uint, itemIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range itemIDs {
+			if err := tx.Model(&models.ChecklistItem{}).Where("id = ? AND checklist_id = ?", id, checklistID).Update("position", i).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
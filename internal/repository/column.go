@@ -56,7 +56,15 @@ func (r *ColumnRepository) Update(column *models.Column) error {
 
 // Delete soft deletes a column
 func (r *ColumnRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Column{}, id).Error
+	// Cards share the column's deletion time so restoring the column brings
+	// back exactly the cards deleted with it
+	now := deletionTime()
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Card{}).Where("column_id = ?", id).Update("deleted_at", now).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Column{}).Where("id = ?", id).Update("deleted_at", now).Error
+	})
 }
 
 // GetMaxPosition returns the maximum position value for columns in a board
@@ -66,11 +74,11 @@ func (r *ColumnRepository) GetMaxPosition(boardID uint) int {
 	return maxPos
 }
 
-// UpdatePositions updates positions for multiple columns in a transaction
-func (r *ColumnRepository) UpdatePositions(columnIDs []uint, positions []int) error {
+// UpdatePositions updates positions for multiple columns of a board in a transaction
+func (r *ColumnRepository) UpdatePositions(boardID uint, columnIDs []uint, positions []int) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		for i, id := range columnIDs {
-			if err := tx.Model(&models.Column{}).Where("id = ?", id).Update("position", positions[i]).Error; err != nil {
+			if err := tx.Model(&models.Column{}).Where("id = ? AND board_id = ?", id, boardID).Update("position", positions[i]).Error; err != nil {
 				return err
 			}
 		}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"github.com/icl00ud/goban/internal/models"
+	"gorm.io/gorm"
+)
+
+type CommentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) *CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+// Create creates a new comment
+func (r *CommentRepository) Create(comment *models.Comment) error {
+	return r.db.Create(comment).Error
+}
+
+// FindByID finds a comment by ID with its author
+func (r *CommentRepository) FindByID(id uint) (*models.Comment, error) {
+	var comment models.Comment
+	err := r.db.Preload("User", withDeletedUsers).First(&comment, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// FindByIDUnscoped finds a comment by ID including deleted ones
+func (r *CommentRepository) FindByIDUnscoped(id uint) (*models.Comment, error) {
+	var comment models.Comment
+	err := r.db.Unscoped().Preload("User", withDeletedUsers).First(&comment, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// FindAllByCardID finds all comments of a card with their authors, oldest first
+func (r *CommentRepository) FindAllByCardID(cardID uint) ([]models.Comment, error) {
+	var comments []models.Comment
+	err := r.db.Preload("User", withDeletedUsers).Where("card_id = ?", cardID).Order("created_at ASC").Find(&comments).Error
+	return comments, err
+}
+
+// FindRevisions finds the edit and delete history of a comment, oldest first
+func (r *CommentRepository) FindRevisions(commentID uint) ([]models.CommentRevision, error) {
+	var revisions []models.CommentRevision
+	err := r.db.Preload("User", withDeletedUsers).Where("comment_id = ?", commentID).Order("created_at ASC").Find(&revisions).Error
+	return revisions, err
+}
+
+// UpdateWithRevision saves a comment together with the revision holding its previous body
+func (r *CommentRepository) UpdateWithRevision(comment *models.Comment, revision *models.CommentRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(revision).Error; err != nil {
+			return err
+		}
+		return tx.Omit("User", "Card").Save(comment).Error
+	})
+}
+
+// DeleteWithRevision soft deletes a comment and records its last body as a revision
+func (r *CommentRepository) DeleteWithRevision(comment *models.Comment, revision *models.CommentRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(revision).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Comment{}, comment.ID).Error
+	})
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/icl00ud/goban/internal/models"
+	"gorm.io/gorm"
+)
+
+// errInviteTaken rolls back a registration whose invite was used meanwhile
+var errInviteTaken = errors.New("invite already used")
+
+type InviteRepository struct {
+	db *gorm.DB
+}
+
+func NewInviteRepository(db *gorm.DB) *InviteRepository {
+	return &InviteRepository{db: db}
+}
+
+// Create creates a new invite
+func (r *InviteRepository) Create(invite *models.Invite) error {
+	return r.db.Create(invite).Error
+}
+
+// FindByID finds an invite by ID
+func (r *InviteRepository) FindByID(id uint) (*models.Invite, error) {
+	var invite models.Invite
+	err := r.db.First(&invite, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// FindByHash finds an invite by the hash of its code
+func (r *InviteRepository) FindByHash(hash string) (*models.Invite, error) {
+	var invite models.Invite
+	err := r.db.Where("code_hash = ?", hash).First(&invite).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// FindAll finds all invites with who created and used them, newest first
+func (r *InviteRepository) FindAll() ([]models.Invite, error) {
+	var invites []models.Invite
+	err := r.db.Preload("CreatedBy", withDeletedUsers).Preload("UsedBy", withDeletedUsers).Order("created_at DESC, id DESC").Find(&invites).Error
+	return invites, err
+}
+
+// Redeem creates a user with an invite, reporting false without creating the
+// user when the invite was already used
+func (r *InviteRepository) Redeem(inviteID uint, user *models.User, usedAt time.Time) (bool, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Invite{}).
+			Where("id = ? AND used_at IS NULL", inviteID).
+			Update("used_at", usedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errInviteTaken
+		}
+
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Invite{}).Where("id = ?", inviteID).Update("used_by_id", user.ID).Error
+	})
+	if errors.Is(err, errInviteTaken) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Delete permanently deletes an invite
+func (r *InviteRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Invite{}, id).Error
+}
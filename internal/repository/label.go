@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"github.com/icl00ud/goban/internal/models"
+	"gorm.io/gorm"
+)
+
+type LabelRepository struct {
+	db *gorm.DB
+}
+
+func NewLabelRepository(db *gorm.DB) *LabelRepository {
+	return &LabelRepository{db: db}
+}
+
+// Create creates a new label
+func (r *LabelRepository) Create(label *models.Label) error {
+	return r.db.Create(label).Error
+}
+
+// FindByID finds a label by ID
+func (r *LabelRepository) FindByID(id uint) (*models.Label, error) {
+	var label models.Label
+	err := r.db.First(&label, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &label, nil
+}
+
+// FindAllByBoardID finds all labels for a board ordered by name
+func (r *LabelRepository) FindAllByBoardID(boardID uint) ([]models.Label, error) {
+	var labels []models.Label
+	err := r.db.Where("board_id = ?", boardID).Order("name ASC").Find(&labels).Error
+	return labels, err
+}
+
+// Update updates a label
+func (r *LabelRepository) Update(label *models.Label) error {
+	return r.db.Save(label).Error
+}
+
+// Delete deletes a label and detaches it from all cards
+func (r *LabelRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM card_labels WHERE label_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Label{}, id).Error
+	})
+}
+
+// Attach adds a label to a card
+func (r *LabelRepository) Attach(card *models.Card, label *models.Label) error {
+	return r.db.Model(card).Association("Labels").Append(label)
+}
+
+// Detach removes a label from a card
+func (r *LabelRepository) Detach(card *models.Card, label *models.Label) error {
+	return r.db.Model(card).Association("Labels").Delete(label)
+}
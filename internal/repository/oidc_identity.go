@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"github.com/icl00ud/goban/internal/models"
+	"gorm.io/gorm"
+)
+
+type OIDCIdentityRepository struct {
+	db *gorm.DB
+}
+
+func NewOIDCIdentityRepository(db *gorm.DB) *OIDCIdentityRepository {
+	return &OIDCIdentityRepository{db: db}
+}
+
+// FindByIssuerSubject finds the identity of a provider account, with its user
+func (r *OIDCIdentityRepository) FindByIssuerSubject(issuer, subject string) (*models.OIDCIdentity, error) {
+	var identity models.OIDCIdentity
+	err := r.db.Preload("User").Where("issuer = ? AND subject = ?", issuer, subject).First(&identity).Error
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// Link creates an identity for an existing user
+func (r *OIDCIdentityRepository) Link(identity *models.OIDCIdentity) error {
+	return r.db.Omit("User").Create(identity).Error
+}
+
+// Provision creates a user together with their provider identity
+func (r *OIDCIdentityRepository) Provision(user *models.User, identity *models.OIDCIdentity) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		identity.UserID = user.ID
+		return tx.Omit("User").Create(identity).Error
+	})
+}
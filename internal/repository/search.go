@@ -0,0 +1,285 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+// Markers wrapped around matched terms in search snippets
+const (
+	HighlightStart = "\x02"
+	HighlightEnd   = "\x03"
+)
+
+// snippetRunes is the approximate length of snippets built for substring search
+const snippetRunes = 120
+
+// Search strategies, chosen by database driver and SQLite build
+const (
+	searchFTS5      = "fts5"
+	searchPostgres  = "postgres"
+	searchSubstring = "substring"
+)
+
+// Search hit types
+const (
+	HitBoard   = "board"
+	HitCard    = "card"
+	HitComment = "comment"
+)
+
+// SearchHit is a ranked search result with its board, column and card context.
+// Higher scores are better matches.
+type SearchHit struct {
+	Type        string
+	ID          uint
+	Title       string
+	Snippet     string
+	Score       float64
+	BoardID     uint
+	BoardName   string
+	ColumnID    uint
+	ColumnTitle string
+	CardID      uint
+	CardTitle   string
+}
+
+type SearchRepository struct {
+	db       *gorm.DB
+	strategy string
+}
+
+func NewSearchRepository(db *gorm.DB) *SearchRepository {
+	strategy := searchSubstring
+	switch db.Dialector.Name() {
+	case "postgres":
+		strategy = searchPostgres
+	case "sqlite":
+		var tables int64
+		db.Raw("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'cards_fts'").Scan(&tables)
+		if tables > 0 {
+			strategy = searchFTS5
+		}
+	}
+
+	return &SearchRepository{db: db, strategy: strategy}
+}
+
+// searchQuery describes how one kind of hit is matched, ranked and joined to
+// its context for the current strategy
+type searchQuery struct {
+	hitType  string
+	selects  string
+	from     string
+	match    string
+	score    string
+	snippet  string
+	liveRows string
+}
+
+// Search finds boards, cards and comments on boards the user can access that
+// match all terms, best matches first
+func (r *SearchRepository) Search(userID uint, terms []string, limit int) ([]SearchHit, error) {
+	args := map[string]interface{}{"user": userID, "limit": limit}
+	for i, term := range terms {
+		args[fmt.Sprintf("term%d", i)] = "%" + term + "%"
+	}
+	args["fts"] = ftsQuery(terms)
+	args["tsquery"] = tsQuery(terms)
+
+	var hits []SearchHit
+	for _, query := range r.queries(terms) {
+		sql := fmt.Sprintf(
+			"SELECT '%s' AS type, %s, %s AS snippet, %s AS score FROM %s WHERE %s AND %s AND %s ORDER BY score DESC LIMIT @limit",
+			query.hitType, query.selects, query.snippet, query.score, query.from, query.match, query.liveRows, accessibleBoards,
+		)
+
+		var page []SearchHit
+		if err := r.db.Raw(sql, args).Scan(&page).Error; err != nil {
+			return nil, err
+		}
+		hits = append(hits, page...)
+	}
+
+	if r.strategy == searchSubstring {
+		for i := range hits {
+			hits[i].Snippet = highlightSnippet(hits[i].Snippet, terms)
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Score > hits[j].Score })
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+// accessibleBoards restricts hits to boards the user created or is a member of
+const accessibleBoards = "(boards.user_id = @user OR boards.id IN (SELECT board_id FROM board_members WHERE user_id = @user))"
+
+func (r *SearchRepository) queries(terms []string) []searchQuery {
+	// Joins from a card to its column and board
+	cardContext := " JOIN columns ON columns.id = cards.column_id JOIN boards ON boards.id = columns.board_id"
+
+	boards := searchQuery{
+		hitType:  HitBoard,
+		selects:  "boards.id AS id, boards.name AS title, boards.id AS board_id, boards.name AS board_name",
+		from:     "boards",
+		liveRows: "boards.deleted_at IS NULL",
+	}
+	cards := searchQuery{
+		hitType: HitCard,
+		selects: "cards.id AS id, cards.title AS title, boards.id AS board_id, boards.name AS board_name, " +
+			"columns.id AS column_id, columns.title AS column_title, cards.id AS card_id, cards.title AS card_title",
+		from:     "cards" + cardContext,
+		liveRows: "cards.deleted_at IS NULL AND columns.deleted_at IS NULL AND boards.deleted_at IS NULL",
+	}
+	comments := searchQuery{
+		hitType: HitComment,
+		selects: "comments.id AS id, cards.title AS title, boards.id AS board_id, boards.name AS board_name, " +
+			"columns.id AS column_id, columns.title AS column_title, cards.id AS card_id, cards.title AS card_title",
+		from:     "comments JOIN cards ON cards.id = comments.card_id" + cardContext,
+		liveRows: "comments.deleted_at IS NULL AND cards.deleted_at IS NULL AND columns.deleted_at IS NULL AND boards.deleted_at IS NULL",
+	}
+
+	switch r.strategy {
+	case searchFTS5:
+		// bm25 scores are lower for better matches; column weights favour card titles
+		boards.from = "boards_fts JOIN boards ON boards.id = boards_fts.rowid"
+		boards.match = "boards_fts MATCH @fts"
+		boards.score = "-bm25(boards_fts)"
+		boards.snippet = "snippet(boards_fts, -1, char(2), char(3), '…', 16)"
+
+		cards.from = "cards_fts JOIN cards ON cards.id = cards_fts.rowid" + cardContext
+		cards.match = "cards_fts MATCH @fts"
+		cards.score = "-bm25(cards_fts, 10.0, 1.0)"
+		cards.snippet = "snippet(cards_fts, -1, char(2), char(3), '…', 16)"
+
+		comments.from = "comments_fts JOIN comments ON comments.id = comments_fts.rowid JOIN cards ON cards.id = comments.card_id" + cardContext
+		comments.match = "comments_fts MATCH @fts"
+		comments.score = "-bm25(comments_fts)"
+		comments.snippet = "snippet(comments_fts, -1, char(2), char(3), '…', 16)"
+	case searchPostgres:
+		headline := "ts_headline('simple', %s, to_tsquery('simple', @tsquery), " +
+			"'StartSel=' || chr(2) || ', StopSel=' || chr(3) || ', MaxWords=24, MinWords=8, MaxFragments=2, FragmentDelimiter=\" … \"')"
+		for _, q := range []*searchQuery{&boards, &cards, &comments} {
+			table := strings.Fields(q.from)[0]
+			q.match = table + ".search_vector @@ to_tsquery('simple', @tsquery)"
+			q.score = "ts_rank(" + table + ".search_vector, to_tsquery('simple', @tsquery))"
+		}
+		boards.snippet = fmt.Sprintf(headline, "boards.name")
+		cards.snippet = fmt.Sprintf(headline, "cards.title || ' ' || coalesce(cards.description, '')")
+		comments.snippet = fmt.Sprintf(headline, "comments.body")
+	default:
+		boards.match = likeAll(terms, "boards.name")
+		cards.match = likeAll(terms, "cards.title", "cards.description")
+		comments.match = likeAll(terms, "comments.body")
+		boards.snippet = "boards.name"
+		cards.snippet = "cards.title || ' ' || coalesce(cards.description, '')"
+		comments.snippet = "comments.body"
+		for _, q := range []*searchQuery{&boards, &cards, &comments} {
+			q.score = "0"
+		}
+	}
+
+	return []searchQuery{boards, cards, comments}
+}
+
+// ftsQuery builds an FTS5 query matching every term as a prefix
+func ftsQuery(terms []string) string {
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = `"` + term + `"*`
+	}
+	return strings.Join(quoted, " ")
+}
+
+// tsQuery builds a PostgreSQL tsquery matching every term as a prefix
+func tsQuery(terms []string) string {
+	prefixes := make([]string, len(terms))
+	for i, term := range terms {
+		prefixes[i] = term + ":*"
+	}
+	return strings.Join(prefixes, " & ")
+}
+
+// likeAll matches rows where every term appears in at least one of the columns
+func likeAll(terms []string, columns ...string) string {
+	conditions := make([]string, len(terms))
+	for i := range terms {
+		matches := make([]string, len(columns))
+		for j, column := range columns {
+			matches[j] = fmt.Sprintf("%s LIKE @term%d", column, i)
+		}
+		conditions[i] = "(" + strings.Join(matches, " OR ") + ")"
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// highlightSnippet cuts a window of text around the first matched term and
+// marks every occurrence of the terms in it
+func highlightSnippet(text string, terms []string) string {
+	lower := strings.ToLower(text)
+
+	start := 0
+	for _, term := range terms {
+		if i := strings.Index(lower, strings.ToLower(term)); i >= 0 {
+			start = i
+			break
+		}
+	}
+
+	// Keep some context before the match, starting on a rune boundary
+	runesBefore := 0
+	for start > 0 && runesBefore < snippetRunes/4 {
+		_, size := utf8.DecodeLastRuneInString(text[:start])
+		start -= size
+		runesBefore++
+	}
+	end := start
+	for n := 0; end < len(text) && n < snippetRunes; n++ {
+		_, size := utf8.DecodeRuneInString(text[end:])
+		end += size
+	}
+
+	window := text[start:end]
+	lowerWindow := strings.ToLower(window)
+	if len(lowerWindow) != len(window) {
+		// Case folding changed byte offsets; return the window unmarked
+		return trimmedWindow(window, start > 0, end < len(text))
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(window); {
+		matched := 0
+		for _, term := range terms {
+			if strings.HasPrefix(lowerWindow[i:], strings.ToLower(term)) && len(term) > matched {
+				matched = len(term)
+			}
+		}
+		if matched > 0 {
+			b.WriteString(HighlightStart + window[i:i+matched] + HighlightEnd)
+			i += matched
+			continue
+		}
+		b.WriteByte(window[i])
+		i++
+	}
+
+	return trimmedWindow(b.String(), start > 0, end < len(text))
+}
+
+// trimmedWindow adds ellipses where a snippet was cut from a longer text
+func trimmedWindow(window string, cutStart, cutEnd bool) string {
+	if cutStart {
+		window = "…" + window
+	}
+	if cutEnd {
+		window += "…"
+	}
+	return window
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/icl00ud/goban/internal/models"
+	"gorm.io/gorm"
+)
+
+type SessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+// Create creates a new session
+func (r *SessionRepository) Create(session *models.Session) error {
+	return r.db.Create(session).Error
+}
+
+// FindByID finds a session by ID
+func (r *SessionRepository) FindByID(id uint) (*models.Session, error) {
+	var session models.Session
+	err := r.db.First(&session, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// FindByJTI finds a session by the JTI its access tokens carry
+func (r *SessionRepository) FindByJTI(jti string) (*models.Session, error) {
+	var session models.Session
+	err := r.db.Where("jti = ?", jti).First(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// FindByRefreshHash finds a session by the hash of its current refresh token,
+// with its user
+func (r *SessionRepository) FindByRefreshHash(hash string) (*models.Session, error) {
+	var session models.Session
+	err := r.db.Preload("User").Where("refresh_token_hash = ?", hash).First(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// FindByPreviousRefreshHash finds a session by the hash of the refresh token
+// it replaced most recently
+func (r *SessionRepository) FindByPreviousRefreshHash(hash string) (*models.Session, error) {
+	var session models.Session
+	err := r.db.Where("previous_refresh_hash = ?", hash).First(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// FindActiveByUserID finds a user's unrevoked, unexpired sessions, most
+// recently seen first
+func (r *SessionRepository) FindActiveByUserID(userID uint, now time.Time) ([]models.Session, error) {
+	var sessions []models.Session
+	err := r.db.
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_seen_at DESC, id DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// Update updates a session
+func (r *SessionRepository) Update(session *models.Session) error {
+	return r.db.Omit("User").Save(session).Error
+}
+
+// TouchLastSeen records when a session was last used
+func (r *SessionRepository) TouchLastSeen(id uint, seenAt time.Time) error {
+	return r.db.Model(&models.Session{}).Where("id = ?", id).Update("last_seen_at", seenAt).Error
+}
+
+// Revoke revokes a session
+func (r *SessionRepository) Revoke(id uint, revokedAt time.Time) error {
+	return r.db.Model(&models.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", revokedAt).Error
+}
+
+// RevokeAllByUserID revokes every active session of a user
+func (r *SessionRepository) RevokeAllByUserID(userID uint, revokedAt time.Time) error {
+	return r.db.Model(&models.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", revokedAt).Error
+}
+
+// DeleteEndedBefore permanently deletes sessions that expired or were revoked
+// before the cutoff
+func (r *SessionRepository) DeleteEndedBefore(cutoff time.Time) error {
+	return r.db.Where("expires_at < ? OR revoked_at < ?", cutoff, cutoff).Delete(&models.Session{}).Error
+}
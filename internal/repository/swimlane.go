@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"github.com/icl00ud/goban/internal/models"
+	"gorm.io/gorm"
+)
+
+type SwimlaneRepository struct {
+	db *gorm.DB
+}
+
+func NewSwimlaneRepository(db *gorm.DB) *SwimlaneRepository {
+	return &SwimlaneRepository{db: db}
+}
+
+// Create creates a new swimlane
+func (r *SwimlaneRepository) Create(swimlane *models.Swimlane) error {
+	return r.db.Create(swimlane).Error
+}
+
+// FindByID finds a swimlane by ID
+func (r *SwimlaneRepository) FindByID(id uint) (*models.Swimlane, error) {
+	var swimlane models.Swimlane
+	err := r.db.First(&swimlane, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &swimlane, nil
+}
+
+// FindAllByBoardID finds all swimlanes for a board ordered by position
+func (r *SwimlaneRepository) FindAllByBoardID(boardID uint) ([]models.Swimlane, error) {
+	var swimlanes []models.Swimlane
+	err := r.db.Where("board_id = ?", boardID).Order("position ASC").Find(&swimlanes).Error
+	return swimlanes, err
+}
+
+// Update updates a swimlane
+func (r *SwimlaneRepository) Update(swimlane *models.Swimlane) error {
+	return r.db.Save(swimlane).Error
+}
+
+// Delete deletes a swimlane and moves its cards, including deleted ones, out
+// of any swimlane
+func (r *SwimlaneRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Card{}).Where("swimlane_id = ?", id).Update("swimlane_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Swimlane{}, id).Error
+	})
+}
+
+// GetMaxPosition returns the maximum position value for swimlanes in a board
+func (r *SwimlaneRepository) GetMaxPosition(boardID uint) int {
+	var maxPos int
+	r.db.Model(&models.Swimlane{}).Where("board_id = ?", boardID).Select("COALESCE(MAX(position), -1)").Scan(&maxPos)
+	return maxPos
+}
+
+// UpdatePositions updates positions for multiple swimlanes of a board in a transaction
+func (r *SwimlaneRepository) UpdatePositions(boardID uint, swimlaneIDs []uint, positions []int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range swimlaneIDs {
+			if err := tx.Model(&models.Swimlane{}).Where("id = ? AND board_id = ?", id, boardID).Update("position", positions[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
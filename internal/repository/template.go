@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"github.com/icl00ud/goban/internal/models"
+	"gorm.io/gorm"
+)
+
+type TemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewTemplateRepository(db *gorm.DB) *TemplateRepository {
+	return &TemplateRepository{db: db}
+}
+
+// Create creates a new template
+func (r *TemplateRepository) Create(template *models.BoardTemplate) error {
+	return r.db.Create(template).Error
+}
+
+// FindByID finds a template by ID
+func (r *TemplateRepository) FindByID(id uint) (*models.BoardTemplate, error) {
+	var template models.BoardTemplate
+	err := r.db.First(&template, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// FindBuiltInByName finds a built-in template by name
+func (r *TemplateRepository) FindBuiltInByName(name string) (*models.BoardTemplate, error) {
+	var template models.BoardTemplate
+	err := r.db.Where("built_in = ? AND name = ?", true, name).First(&template).Error
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// FindAllForUser finds the built-in templates followed by the user's own,
+// each ordered by name
+func (r *TemplateRepository) FindAllForUser(userID uint) ([]models.BoardTemplate, error) {
+	var templates []models.BoardTemplate
+	err := r.db.
+		Where("built_in = ? OR user_id = ?", true, userID).
+		Order("built_in DESC, name ASC").
+		Find(&templates).Error
+	return templates, err
+}
+
+// Update updates a template
+func (r *TemplateRepository) Update(template *models.BoardTemplate) error {
+	return r.db.Save(template).Error
+}
+
+// Delete deletes a template
+func (r *TemplateRepository) Delete(id uint) error {
+	return r.db.Delete(&models.BoardTemplate{}, id).Error
+}
+
+// CreateBoard creates a board laid out like a template structure, with its
+// labels, swimlanes, columns and sample cards, in a transaction
+func (r *TemplateRepository) CreateBoard(board *models.Board, structure *models.TemplateStructure) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(board).Error; err != nil {
+			return err
+		}
+
+		labels := make(map[string]models.Label, len(structure.Labels))
+		for _, tl := range structure.Labels {
+			label := models.Label{Name: tl.Name, Color: tl.Color, BoardID: board.ID}
+			if err := tx.Create(&label).Error; err != nil {
+				return err
+			}
+			labels[tl.Name] = label
+		}
+
+		swimlanes := make(map[string]uint, len(structure.Swimlanes))
+		for i, title := range structure.Swimlanes {
+			swimlane := models.Swimlane{Title: title, Position: i, BoardID: board.ID}
+			if err := tx.Create(&swimlane).Error; err != nil {
+				return err
+			}
+			swimlanes[title] = swimlane.ID
+		}
+
+		for i, tc := range structure.Columns {
+			column := models.Column{Title: tc.Title, Position: i, BoardID: board.ID, WIPLimit: tc.WIPLimit}
+			if err := tx.Create(&column).Error; err != nil {
+				return err
+			}
+
+			for j, tcard := range tc.Cards {
+				card := models.Card{
+					Title:       tcard.Title,
+					Description: tcard.Description,
+					Priority:    tcard.Priority,
+					Position:    j,
+					ColumnID:    column.ID,
+				}
+				if id, ok := swimlanes[tcard.Swimlane]; ok {
+					card.SwimlaneID = &id
+				}
+				for _, name := range tcard.Labels {
+					if label, ok := labels[name]; ok {
+						card.Labels = append(card.Labels, label)
+					}
+				}
+				if err := tx.Create(&card).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,280 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/icl00ud/goban/internal/models"
+	"gorm.io/gorm"
+)
+
+// purgeBatchSize bounds the number of IDs bound in a single statement
+const purgeBatchSize = 500
+
+type TrashRepository struct {
+	db *gorm.DB
+}
+
+func NewTrashRepository(db *gorm.DB) *TrashRepository {
+	return &TrashRepository{db: db}
+}
+
+// deletionTime returns the timestamp stored when soft-deleting an entity and
+// its children, truncated to the precision every database driver keeps
+func deletionTime() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// FindDeletedBoards finds deleted boards the user owns, most recently deleted first
+func (r *TrashRepository) FindDeletedBoards(userID uint) ([]models.Board, error) {
+	var boards []models.Board
+	err := r.db.Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Where("user_id = ? OR id IN (?)", userID, r.memberBoardIDs(userID, models.RoleOwner)).
+		Order("deleted_at DESC").
+		Find(&boards).Error
+	return boards, err
+}
+
+// FindDeletedColumns finds deleted columns of live boards the user can edit,
+// most recently deleted first
+func (r *TrashRepository) FindDeletedColumns(userID uint) ([]models.Column, error) {
+	var columns []models.Column
+	err := r.db.Unscoped().
+		Select("columns.*").
+		Joins("JOIN boards ON boards.id = columns.board_id AND boards.deleted_at IS NULL").
+		Where("columns.deleted_at IS NOT NULL").
+		Where("boards.user_id = ? OR boards.id IN (?)", userID, r.memberBoardIDs(userID, models.RoleEditor, models.RoleOwner)).
+		Preload("Board").
+		Order("columns.deleted_at DESC").
+		Find(&columns).Error
+	return columns, err
+}
+
+// FindDeletedCards finds deleted cards in live columns the user can edit,
+// most recently deleted first
+func (r *TrashRepository) FindDeletedCards(userID uint) ([]models.Card, error) {
+	var cards []models.Card
+	err := r.db.Unscoped().
+		Select("cards.*").
+		Joins("JOIN columns ON columns.id = cards.column_id AND columns.deleted_at IS NULL").
+		Joins("JOIN boards ON boards.id = columns.board_id AND boards.deleted_at IS NULL").
+		Where("cards.deleted_at IS NOT NULL").
+		Where("boards.user_id = ? OR boards.id IN (?)", userID, r.memberBoardIDs(userID, models.RoleEditor, models.RoleOwner)).
+		Preload("Column.Board").
+		Order("cards.deleted_at DESC").
+		Find(&cards).Error
+	return cards, err
+}
+
+// memberBoardIDs selects the boards where the user is a member with one of the roles
+func (r *TrashRepository) memberBoardIDs(userID uint, roles ...string) *gorm.DB {
+	return r.db.Model(&models.BoardMember{}).Select("board_id").Where("user_id = ? AND role IN ?", userID, roles)
+}
+
+// FindDeletedBoard finds a board in the trash
+func (r *TrashRepository) FindDeletedBoard(id uint) (*models.Board, error) {
+	var board models.Board
+	err := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&board, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
+
+// FindDeletedColumn finds a column in the trash
+func (r *TrashRepository) FindDeletedColumn(id uint) (*models.Column, error) {
+	var column models.Column
+	err := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&column, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &column, nil
+}
+
+// FindDeletedCard finds a card in the trash
+func (r *TrashRepository) FindDeletedCard(id uint) (*models.Card, error) {
+	var card models.Card
+	err := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&card, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// RestoreBoard restores a board at the given position together with the
+// columns and cards deleted along with it
+func (r *TrashRepository) RestoreBoard(board *models.Board, position int) error {
+	deletedAt := board.DeletedAt.Time
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		columnIDs := tx.Unscoped().Model(&models.Column{}).Select("id").Where("board_id = ?", board.ID)
+		if err := tx.Unscoped().Model(&models.Card{}).
+			Where("column_id IN (?) AND deleted_at >= ?", columnIDs, deletedAt).
+			Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&models.Column{}).
+			Where("board_id = ? AND deleted_at >= ?", board.ID, deletedAt).
+			Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Model(&models.Board{}).
+			Where("id = ?", board.ID).
+			Updates(map[string]interface{}{"deleted_at": nil, "position": position}).Error
+	})
+}
+
+// RestoreColumn restores a column at the given position together with the
+// cards deleted along with it
+func (r *TrashRepository) RestoreColumn(column *models.Column, position int) error {
+	deletedAt := column.DeletedAt.Time
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Card{}).
+			Where("column_id = ? AND deleted_at >= ?", column.ID, deletedAt).
+			Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Model(&models.Column{}).
+			Where("id = ?", column.ID).
+			Updates(map[string]interface{}{"deleted_at": nil, "position": position}).Error
+	})
+}
+
+// RestoreCard restores a card at the given position in its column
+func (r *TrashRepository) RestoreCard(card *models.Card, position int) error {
+	return r.db.Unscoped().Model(&models.Card{}).
+		Where("id = ?", card.ID).
+		Updates(map[string]interface{}{"deleted_at": nil, "position": position}).Error
+}
+
+// PurgeDeletedBefore permanently deletes boards, columns and cards that were
+// deleted before the cutoff, along with everything that belongs to them. It
+// returns the storage keys of the purged attachments so their contents can
+// be removed.
+func (r *TrashRepository) PurgeDeletedBefore(cutoff time.Time) ([]string, error) {
+	var storageKeys []string
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Include soft-deleted rows in every statement below
+		tx = tx.Unscoped().Session(&gorm.Session{})
+
+		var boardIDs []uint
+		if err := tx.Model(&models.Board{}).Where("deleted_at < ?", cutoff).Pluck("id", &boardIDs).Error; err != nil {
+			return err
+		}
+
+		var columnIDs []uint
+		columns := tx.Model(&models.Column{}).Where("deleted_at < ?", cutoff)
+		if len(boardIDs) > 0 {
+			columns = columns.Or("board_id IN ?", boardIDs)
+		}
+		if err := columns.Pluck("id", &columnIDs).Error; err != nil {
+			return err
+		}
+
+		var cardIDs []uint
+		cards := tx.Model(&models.Card{}).Where("deleted_at < ?", cutoff)
+		if len(columnIDs) > 0 {
+			cards = cards.Or("column_id IN ?", columnIDs)
+		}
+		if err := cards.Pluck("id", &cardIDs).Error; err != nil {
+			return err
+		}
+
+		for _, batch := range chunkIDs(cardIDs) {
+			keys, err := purgeCards(tx, batch)
+			if err != nil {
+				return err
+			}
+			storageKeys = append(storageKeys, keys...)
+		}
+
+		for _, batch := range chunkIDs(columnIDs) {
+			if err := tx.Where("id IN ?", batch).Delete(&models.Column{}).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, batch := range chunkIDs(boardIDs) {
+			if err := purgeBoards(tx, batch); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return storageKeys, err
+}
+
+// purgeCards hard-deletes cards and their comments, checklists, attachments,
+// assignees and labels, returning the attachment storage keys
+func purgeCards(tx *gorm.DB, cardIDs []uint) ([]string, error) {
+	var storageKeys []string
+	if err := tx.Model(&models.Attachment{}).Where("card_id IN ?", cardIDs).Pluck("storage_key", &storageKeys).Error; err != nil {
+		return nil, err
+	}
+
+	commentIDs := tx.Model(&models.Comment{}).Select("id").Where("card_id IN ?", cardIDs)
+	if err := tx.Where("comment_id IN (?)", commentIDs).Delete(&models.CommentRevision{}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("card_id IN ?", cardIDs).Delete(&models.Comment{}).Error; err != nil {
+		return nil, err
+	}
+
+	checklistIDs := tx.Model(&models.Checklist{}).Select("id").Where("card_id IN ?", cardIDs)
+	if err := tx.Where("checklist_id IN (?)", checklistIDs).Delete(&models.ChecklistItem{}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("card_id IN ?", cardIDs).Delete(&models.Checklist{}).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Where("card_id IN ?", cardIDs).Delete(&models.Attachment{}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Exec("DELETE FROM card_assignees WHERE card_id IN ?", cardIDs).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Exec("DELETE FROM card_labels WHERE card_id IN ?", cardIDs).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Where("id IN ?", cardIDs).Delete(&models.Card{}).Error; err != nil {
+		return nil, err
+	}
+
+	return storageKeys, nil
+}
+
+// purgeBoards hard-deletes boards and their labels, swimlanes, members and
+// activity.
+// Their columns and cards must already be purged.
+func purgeBoards(tx *gorm.DB, boardIDs []uint) error {
+	if err := tx.Where("board_id IN ?", boardIDs).Delete(&models.Label{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("board_id IN ?", boardIDs).Delete(&models.Swimlane{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("board_id IN ?", boardIDs).Delete(&models.BoardMember{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("board_id IN ?", boardIDs).Delete(&models.Activity{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("id IN ?", boardIDs).Delete(&models.Board{}).Error
+}
+
+// chunkIDs splits IDs into batches of at most purgeBatchSize
+func chunkIDs(ids []uint) [][]uint {
+	var batches [][]uint
+	for len(ids) > purgeBatchSize {
+		batches = append(batches, ids[:purgeBatchSize])
+		ids = ids[purgeBatchSize:]
+	}
+	if len(ids) > 0 {
+		batches = append(batches, ids)
+	}
+	return batches
+}
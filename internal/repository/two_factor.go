@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/icl00ud/goban/internal/models"
+	"gorm.io/gorm"
+)
+
+type TwoFactorRepository struct {
+	db *gorm.DB
+}
+
+func NewTwoFactorRepository(db *gorm.DB) *TwoFactorRepository {
+	return &TwoFactorRepository{db: db}
+}
+
+// ReplaceRecoveryCodes replaces all of a user's recovery codes
+func (r *TwoFactorRepository) ReplaceRecoveryCodes(userID uint, codeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		codes := make([]models.RecoveryCode, len(codeHashes))
+		for i, hash := range codeHashes {
+			codes[i] = models.RecoveryCode{UserID: userID, CodeHash: hash}
+		}
+		return tx.Omit("User").Create(&codes).Error
+	})
+}
+
+// UseRecoveryCode marks an unused recovery code as used, reporting whether
+// the user had such a code
+func (r *TwoFactorRepository) UseRecoveryCode(userID uint, codeHash string, usedAt time.Time) (bool, error) {
+	result := r.db.Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", usedAt)
+	return result.RowsAffected > 0, result.Error
+}
+
+// CountUnusedRecoveryCodes counts a user's remaining recovery codes
+func (r *TwoFactorRepository) CountUnusedRecoveryCodes(userID uint) int64 {
+	var count int64
+	r.db.Model(&models.RecoveryCode{}).Where("user_id = ? AND used_at IS NULL", userID).Count(&count)
+	return count
+}
+
+// DeleteRecoveryCodes deletes all of a user's recovery codes
+func (r *TwoFactorRepository) DeleteRecoveryCodes(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error
+}
+
+// CreateChallenge creates a login challenge
+func (r *TwoFactorRepository) CreateChallenge(challenge *models.LoginChallenge) error {
+	return r.db.Omit("User").Create(challenge).Error
+}
+
+// FindChallengeByHash finds a login challenge by the hash of its token, with
+// its user
+func (r *TwoFactorRepository) FindChallengeByHash(hash string) (*models.LoginChallenge, error) {
+	var challenge models.LoginChallenge
+	err := r.db.Preload("User").Where("token_hash = ?", hash).First(&challenge).Error
+	if err != nil {
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+// IncrementChallengeAttempts counts a failed attempt at a login challenge
+func (r *TwoFactorRepository) IncrementChallengeAttempts(id uint) error {
+	return r.db.Model(&models.LoginChallenge{}).Where("id = ?", id).
+		Update("attempts", gorm.Expr("attempts + 1")).Error
+}
+
+// DeleteChallenge deletes a login challenge
+func (r *TwoFactorRepository) DeleteChallenge(id uint) error {
+	return r.db.Delete(&models.LoginChallenge{}, id).Error
+}
+
+// DeleteExpiredChallenges deletes login challenges that expired before now
+func (r *TwoFactorRepository) DeleteExpiredChallenges(now time.Time) error {
+	return r.db.Where("expires_at < ?", now).Delete(&models.LoginChallenge{}).Error
+}
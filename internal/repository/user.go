@@ -1,6 +1,10 @@
 package repository
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/icl00ud/goban/internal/models"
 	"gorm.io/gorm"
 )
@@ -38,9 +42,131 @@ func (r *UserRepository) FindByEmail(email string) (*models.User, error) {
 	return &user, nil
 }
 
+// FindByEmailFold finds a user by email, ignoring case
+func (r *UserRepository) FindByEmailFold(email string) (*models.User, error) {
+	var user models.User
+	err := r.db.Where("LOWER(email) = LOWER(?)", email).Order("id ASC").First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
 // ExistsByEmail checks if a user with the given email exists
 func (r *UserRepository) ExistsByEmail(email string) bool {
 	var count int64
 	r.db.Model(&models.User{}).Where("email = ?", email).Count(&count)
 	return count > 0
 }
+
+// UpdateTOTP saves a user's TOTP secret, enrolment state and last used step
+func (r *UserRepository) UpdateTOTP(user *models.User) error {
+	return r.db.Model(user).Select("totp_secret", "totp_enabled", "totp_last_step").Updates(user).Error
+}
+
+// ClaimTOTPStep records a TOTP time step as used, reporting false when that
+// step or a later one was already used
+func (r *UserRepository) ClaimTOTPStep(userID uint, step int64) (bool, error) {
+	result := r.db.Model(&models.User{}).
+		Where("id = ? AND totp_last_step < ?", userID, step).
+		Update("totp_last_step", step)
+	return result.RowsAffected > 0, result.Error
+}
+
+// MarkEmailVerified records when a user verified their email address
+func (r *UserRepository) MarkEmailVerified(userID uint, verifiedAt time.Time) error {
+	return r.db.Model(&models.User{}).
+		Where("id = ? AND email_verified_at IS NULL", userID).
+		Update("email_verified_at", verifiedAt).Error
+}
+
+// RecordLoginFailure counts a failed login. Reaching maxFailures locks the
+// account until lockedUntil and starts the count over.
+func (r *UserRepository) RecordLoginFailure(userID uint, maxFailures int, lockedUntil time.Time) error {
+	return r.db.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"failed_logins": gorm.Expr("CASE WHEN failed_logins + 1 >= ? THEN 0 ELSE failed_logins + 1 END", maxFailures),
+		"locked_until":  gorm.Expr("CASE WHEN failed_logins + 1 >= ? THEN ? ELSE locked_until END", maxFailures, lockedUntil),
+	}).Error
+}
+
+// ResetLoginFailures clears a user's failed login count and any lockout
+func (r *UserRepository) ResetLoginFailures(userID uint) error {
+	return r.db.Model(&models.User{}).
+		Where("id = ? AND (failed_logins > 0 OR locked_until IS NOT NULL)", userID).
+		Updates(map[string]interface{}{"failed_logins": 0, "locked_until": nil}).Error
+}
+
+// UpdatePassword replaces a user's password hash
+func (r *UserRepository) UpdatePassword(userID uint, passwordHash string) error {
+	return r.db.Model(&models.User{}).Where("id = ?", userID).Update("password_hash", passwordHash).Error
+}
+
+// FindAll finds all users, optionally only those whose name or email contains
+// query, ordered by ID
+func (r *UserRepository) FindAll(query string) ([]models.User, error) {
+	var users []models.User
+	db := r.db.Order("id ASC")
+	if query != "" {
+		like := "%" + strings.ToLower(query) + "%"
+		db = db.Where("LOWER(email) LIKE ? OR LOWER(name) LIKE ?", like, like)
+	}
+	err := db.Find(&users).Error
+	return users, err
+}
+
+// PromoteIfFirst makes the user an administrator if they are the first user
+// ever created, reporting whether they were promoted
+func (r *UserRepository) PromoteIfFirst(userID uint) (bool, error) {
+	first := r.db.Unscoped().Model(&models.User{}).Select("MIN(id)")
+	result := r.db.Model(&models.User{}).
+		Where("id = ? AND id = (?)", userID, first).
+		Update("is_admin", true)
+	return result.RowsAffected > 0, result.Error
+}
+
+// SetAdmin grants or revokes a user's administrator role
+func (r *UserRepository) SetAdmin(userID uint, isAdmin bool) error {
+	return r.db.Model(&models.User{}).Where("id = ?", userID).Update("is_admin", isAdmin).Error
+}
+
+// SetDisabled disables a user from disabledAt, or enables them again when
+// disabledAt is nil
+func (r *UserRepository) SetDisabled(userID uint, disabledAt *time.Time) error {
+	return r.db.Model(&models.User{}).Where("id = ?", userID).Update("disabled_at", disabledAt).Error
+}
+
+// withDeletedUsers preloads users even after they were deleted, so their name
+// stays on what they wrote and did
+func withDeletedUsers(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
+// Delete soft deletes a user and removes everything that lets them sign in or
+// see boards. The email address is released so it can register again, while
+// the name stays on the user's comments and activity.
+func (r *UserRepository) Delete(userID uint, deletedAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{
+			&models.Session{},
+			&models.APIToken{},
+			&models.OIDCIdentity{},
+			&models.RecoveryCode{},
+			&models.LoginChallenge{},
+			&models.UserToken{},
+			&models.BoardMember{},
+		} {
+			if err := tx.Where("user_id = ?", userID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Exec("DELETE FROM card_assignees WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"email":      fmt.Sprintf("deleted-%d@deleted.invalid", userID),
+			"is_admin":   false,
+			"deleted_at": deletedAt,
+		}).Error
+	})
+}
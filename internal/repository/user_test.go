@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/icl00ud/goban/internal/config"
+	"github.com/icl00ud/goban/internal/database"
+	"github.com/icl00ud/goban/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDB opens a migrated SQLite database that is removed after the test
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := database.Connect(&config.Config{
+		DBDriver:    "sqlite",
+		DatabaseURL: filepath.Join(t.TempDir(), "goban.db"),
+	})
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	db.Logger = logger.Default.LogMode(logger.Silent)
+	if err := database.Migrate(db); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+func mustCreate(t *testing.T, db *gorm.DB, value interface{}) {
+	t.Helper()
+	if err := db.Create(value).Error; err != nil {
+		t.Fatalf("failed to create %T: %v", value, err)
+	}
+}
+
+func TestDeletedUserStaysOnComments(t *testing.T) {
+	db := newTestDB(t)
+	userRepo := NewUserRepository(db)
+	commentRepo := NewCommentRepository(db)
+	activityRepo := NewActivityRepository(db)
+
+	owner := &models.User{Email: "owner@example.com", PasswordHash: "x", Name: "Owner"}
+	author := &models.User{Email: "author@example.com", PasswordHash: "x", Name: "Ada"}
+	mustCreate(t, db, owner)
+	mustCreate(t, db, author)
+
+	board := &models.Board{Name: "Board", UserID: owner.ID}
+	mustCreate(t, db, board)
+	column := &models.Column{Title: "To Do", BoardID: board.ID}
+	mustCreate(t, db, column)
+	card := &models.Card{Title: "Card", ColumnID: column.ID}
+	mustCreate(t, db, card)
+
+	comment := &models.Comment{Body: "first draft", CardID: card.ID, UserID: author.ID}
+	mustCreate(t, db, comment)
+	comment.Body = "edited"
+	revision := &models.CommentRevision{CommentID: comment.ID, Action: models.RevisionEdited, Body: "first draft", UserID: author.ID}
+	if err := commentRepo.UpdateWithRevision(comment, revision); err != nil {
+		t.Fatalf("failed to edit comment: %v", err)
+	}
+	mustCreate(t, db, &models.Activity{BoardID: board.ID, UserID: author.ID, EntityType: models.EntityCard, EntityID: card.ID, Action: models.ActionCreated})
+
+	if err := userRepo.Delete(author.ID, time.Now().UTC()); err != nil {
+		t.Fatalf("failed to delete user: %v", err)
+	}
+	if _, err := userRepo.FindByID(author.ID); err == nil {
+		t.Fatal("deleted user is still found")
+	}
+
+	comments, err := commentRepo.FindAllByCardID(card.ID)
+	if err != nil {
+		t.Fatalf("failed to list comments: %v", err)
+	}
+	if len(comments) != 1 {
+		t.Fatalf("got %d comments, want 1", len(comments))
+	}
+	if comments[0].User.ID != author.ID || comments[0].User.Name != "Ada" {
+		t.Errorf("comment author = %d %q, want %d %q", comments[0].User.ID, comments[0].User.Name, author.ID, "Ada")
+	}
+
+	revisions, err := commentRepo.FindRevisions(comment.ID)
+	if err != nil {
+		t.Fatalf("failed to list revisions: %v", err)
+	}
+	if len(revisions) != 1 || revisions[0].User.Name != "Ada" {
+		t.Errorf("got %d revisions, want one by %q", len(revisions), "Ada")
+	}
+
+	activities, err := activityRepo.FindPageByBoardID(board.ID, 0, 10)
+	if err != nil {
+		t.Fatalf("failed to list activity: %v", err)
+	}
+	if len(activities) != 1 || activities[0].User.Name != "Ada" {
+		t.Errorf("got %d activities, want one by %q", len(activities), "Ada")
+	}
+}
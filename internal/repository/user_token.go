@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/icl00ud/goban/internal/models"
+	"gorm.io/gorm"
+)
+
+type UserTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewUserTokenRepository(db *gorm.DB) *UserTokenRepository {
+	return &UserTokenRepository{db: db}
+}
+
+// Create creates a new user token
+func (r *UserTokenRepository) Create(token *models.UserToken) error {
+	return r.db.Omit("User").Create(token).Error
+}
+
+// FindByHash finds a token for a purpose by the hash of its secret, with its user
+func (r *UserTokenRepository) FindByHash(purpose, hash string) (*models.UserToken, error) {
+	var token models.UserToken
+	err := r.db.Preload("User").Where("purpose = ? AND token_hash = ?", purpose, hash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUsed marks an unused token as used, reporting false when it was
+// already used
+func (r *UserTokenRepository) MarkUsed(id uint, usedAt time.Time) (bool, error) {
+	result := r.db.Model(&models.UserToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", usedAt)
+	return result.RowsAffected > 0, result.Error
+}
+
+// InvalidateAll marks every unused token of a user for a purpose as used
+func (r *UserTokenRepository) InvalidateAll(userID uint, purpose string, usedAt time.Time) error {
+	return r.db.Model(&models.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", usedAt).Error
+}
+
+// DeleteExpiredBefore permanently deletes tokens that expired before the cutoff
+func (r *UserTokenRepository) DeleteExpiredBefore(cutoff time.Time) error {
+	return r.db.Where("expires_at < ?", cutoff).Delete(&models.UserToken{}).Error
+}
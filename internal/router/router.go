@@ -1,34 +1,118 @@
 package router
 
 import (
+	"log"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/icl00ud/goban/internal/config"
+	"github.com/icl00ud/goban/internal/events"
 	"github.com/icl00ud/goban/internal/handlers"
+	"github.com/icl00ud/goban/internal/mail"
 	"github.com/icl00ud/goban/internal/middleware"
+	"github.com/icl00ud/goban/internal/oidc"
 	"github.com/icl00ud/goban/internal/repository"
 	"github.com/icl00ud/goban/internal/services"
+	"github.com/icl00ud/goban/internal/storage"
 	"gorm.io/gorm"
 )
 
-func Setup(app *fiber.App, db *gorm.DB, cfg *config.Config) {
+func Setup(app *fiber.App, db *gorm.DB, cfg *config.Config, store storage.Storage, mailer mail.Mailer) {
 	// Initialize repositories
 	userRepo := repository.NewUserRepository(db)
 	boardRepo := repository.NewBoardRepository(db)
 	columnRepo := repository.NewColumnRepository(db)
 	cardRepo := repository.NewCardRepository(db)
+	memberRepo := repository.NewBoardMemberRepository(db)
+	labelRepo := repository.NewLabelRepository(db)
+	swimlaneRepo := repository.NewSwimlaneRepository(db)
+	templateRepo := repository.NewTemplateRepository(db)
+	commentRepo := repository.NewCommentRepository(db)
+	checklistRepo := repository.NewChecklistRepository(db)
+	attachmentRepo := repository.NewAttachmentRepository(db)
+	activityRepo := repository.NewActivityRepository(db)
+	trashRepo := repository.NewTrashRepository(db)
+	searchRepo := repository.NewSearchRepository(db)
+	apiTokenRepo := repository.NewAPITokenRepository(db)
+	sessionRepo := repository.NewSessionRepository(db)
+	identityRepo := repository.NewOIDCIdentityRepository(db)
+	twoFactorRepo := repository.NewTwoFactorRepository(db)
+	userTokenRepo := repository.NewUserTokenRepository(db)
+	inviteRepo := repository.NewInviteRepository(db)
+
+	// Real-time event broker shared by services and the event stream
+	broker := events.NewBroker()
 
 	// Initialize services
-	authService := services.NewAuthService(userRepo, cfg.JWTSecret)
-	boardService := services.NewBoardService(boardRepo, columnRepo)
-	columnService := services.NewColumnService(columnRepo, boardRepo)
-	cardService := services.NewCardService(cardRepo, columnRepo, boardRepo)
+	registration := services.RegistrationPolicy{Mode: cfg.RegistrationMode, AllowedDomains: cfg.RegistrationAllowedDomains}
+	if !services.ValidRegistrationMode(registration.Mode) {
+		log.Printf("Unknown REGISTRATION_MODE %q, registration is closed", registration.Mode)
+		registration.Mode = services.RegistrationClosed
+	}
+	authService := services.NewAuthService(userRepo, inviteRepo, registration, cfg.LoginMaxFailures, cfg.LoginLockoutDuration)
+	sessionService := services.NewSessionService(sessionRepo, cfg.JWTSecret)
+	twoFactorService := services.NewTwoFactorService(userRepo, twoFactorRepo, cfg.LoginMaxFailures, cfg.LoginLockoutDuration)
+	accountService := services.NewAccountService(userRepo, userTokenRepo, sessionService, mailer, cfg.AppURL)
+	adminService := services.NewAdminService(userRepo, boardRepo, inviteRepo, sessionService, accountService, cfg.AppURL)
+	var oidcProvider *oidc.Provider
+	if cfg.OIDCEnabled() {
+		oidcProvider = oidc.NewProvider(oidc.Config{
+			Issuer:       cfg.OIDCIssuer,
+			ClientID:     cfg.OIDCClientID,
+			ClientSecret: cfg.OIDCClientSecret,
+			RedirectURL:  cfg.OIDCRedirectURL,
+		})
+	}
+	oidcService := services.NewOIDCService(oidcProvider, identityRepo, userRepo, cfg.OIDCAllowedDomains, registration)
+	apiTokenService := services.NewAPITokenService(apiTokenRepo)
+	boardService := services.NewBoardService(boardRepo, columnRepo, templateRepo, activityRepo, broker)
+	columnService := services.NewColumnService(columnRepo, boardRepo, activityRepo, broker)
+	cardService := services.NewCardService(cardRepo, columnRepo, boardRepo, userRepo, swimlaneRepo, activityRepo, broker)
+	memberService := services.NewMemberService(memberRepo, boardRepo, userRepo)
+	labelService := services.NewLabelService(labelRepo, cardRepo, columnRepo, boardRepo, broker)
+	swimlaneService := services.NewSwimlaneService(swimlaneRepo, boardRepo, activityRepo, broker)
+	templateService := services.NewTemplateService(templateRepo, boardRepo, labelRepo)
+	exportService := services.NewExportService(boardRepo, labelRepo, checklistRepo, commentRepo, userRepo, activityRepo, broker)
+	commentService := services.NewCommentService(commentRepo, cardRepo, boardRepo, broker)
+	checklistService := services.NewChecklistService(checklistRepo, cardRepo, boardRepo, broker)
+	activityService := services.NewActivityService(activityRepo, cardRepo, boardRepo)
+	searchService := services.NewSearchService(searchRepo)
+	trashService := services.NewTrashService(trashRepo, boardRepo, columnRepo, cardRepo, activityRepo, store, broker, cfg.TrashRetentionDays)
+	attachmentService := services.NewAttachmentService(attachmentRepo, cardRepo, boardRepo, store, broker, cfg.MaxUploadSize, cfg.AllowedUploadTypes)
 
 	// Initialize handlers
 	healthHandler := handlers.NewHealthHandler()
-	authHandler := handlers.NewAuthHandler(authService)
+	authHandler := handlers.NewAuthHandler(authService, sessionService, twoFactorService, accountService)
+	twoFactorHandler := handlers.NewTwoFactorHandler(twoFactorService)
+	sessionHandler := handlers.NewSessionHandler(sessionService)
+	oidcHandler := handlers.NewOIDCHandler(oidcService, sessionService)
+	apiTokenHandler := handlers.NewAPITokenHandler(apiTokenService)
+	adminHandler := handlers.NewAdminHandler(adminService)
 	boardHandler := handlers.NewBoardHandler(boardService)
 	columnHandler := handlers.NewColumnHandler(columnService)
 	cardHandler := handlers.NewCardHandler(cardService)
+	memberHandler := handlers.NewMemberHandler(memberService)
+	eventHandler := handlers.NewEventHandler(boardService, sessionService, apiTokenService, broker)
+	labelHandler := handlers.NewLabelHandler(labelService)
+	swimlaneHandler := handlers.NewSwimlaneHandler(swimlaneService)
+	templateHandler := handlers.NewTemplateHandler(templateService)
+	exportHandler := handlers.NewExportHandler(exportService)
+	commentHandler := handlers.NewCommentHandler(commentService)
+	checklistHandler := handlers.NewChecklistHandler(checklistService)
+	attachmentHandler := handlers.NewAttachmentHandler(attachmentService)
+	activityHandler := handlers.NewActivityHandler(activityService)
+	trashHandler := handlers.NewTrashHandler(trashService)
+	searchHandler := handlers.NewSearchHandler(searchService)
+
+	// Purge expired trash in the background
+	trashService.StartPurger()
+
+	// Delete long-ended sessions in the background
+	sessionService.StartPruner()
+
+	// Keep the built-in board templates up to date
+	if err := templateService.SyncBuiltIns(); err != nil {
+		log.Printf("Failed to sync built-in board templates: %v", err)
+	}
 
 	// API group
 	api := app.Group("/api/v1")
@@ -36,37 +120,158 @@ func Setup(app *fiber.App, db *gorm.DB, cfg *config.Config) {
 	// Health check (public)
 	api.Get("/health", healthHandler.Check)
 
+	// Credential endpoints share a per-IP budget; login and password reset
+	// emails are also limited per account
+	authLimit := middleware.RateLimitByIP(cfg.AuthRateLimit, cfg.AuthRateWindow)
+	accountLimit := middleware.RateLimitByAccount(cfg.AccountRateLimit, cfg.AccountRateWindow)
+
 	// Auth routes (public)
 	auth := api.Group("/auth")
-	auth.Post("/register", authHandler.Register)
-	auth.Post("/login", authHandler.Login)
+	auth.Get("/registration", authHandler.RegistrationPolicy)
+	auth.Post("/register", authLimit, authHandler.Register)
+	auth.Post("/login", authLimit, accountLimit, authHandler.Login)
+	auth.Post("/login/2fa", authLimit, authHandler.LoginTwoFactor)
+	auth.Post("/refresh", authHandler.Refresh)
 	auth.Post("/logout", authHandler.Logout)
+	auth.Post("/forgot-password", authLimit, accountLimit, authHandler.ForgotPassword)
+	auth.Post("/reset-password", authLimit, authHandler.ResetPassword)
+	auth.Post("/verify-email", authLimit, authHandler.VerifyEmail)
+	auth.Get("/oidc/login", oidcHandler.Login)
+	auth.Get("/oidc/callback", oidcHandler.Callback)
 
 	// Protected auth routes
-	auth.Get("/me", middleware.AuthMiddleware(cfg.JWTSecret), authHandler.Me)
+	requireAuth := middleware.AuthMiddleware(sessionService, apiTokenService)
+	auth.Get("/me", requireAuth, authHandler.Me)
+	auth.Post("/verify-email/resend", requireAuth, authHandler.ResendVerification)
+
+	// Personal API tokens can only be managed from a logged-in session
+	tokens := auth.Group("/tokens", requireAuth, middleware.SessionOnly())
+	tokens.Get("", apiTokenHandler.List)
+	tokens.Post("", apiTokenHandler.Create)
+	tokens.Delete("/:id", apiTokenHandler.Revoke)
+
+	// Two-factor authentication
+	twoFactor := auth.Group("/2fa", requireAuth, middleware.SessionOnly())
+	twoFactor.Get("", twoFactorHandler.Status)
+	twoFactor.Post("/setup", twoFactorHandler.Setup)
+	twoFactor.Post("/confirm", twoFactorHandler.Confirm)
+	twoFactor.Post("/disable", twoFactorHandler.Disable)
+	twoFactor.Post("/recovery-codes", twoFactorHandler.RegenerateRecoveryCodes)
+
+	// Signed-in devices
+	sessions := auth.Group("/sessions", requireAuth, middleware.SessionOnly())
+	sessions.Get("", sessionHandler.List)
+	sessions.Delete("", sessionHandler.RevokeAll)
+	sessions.Delete("/:id", sessionHandler.Revoke)
+
+	// Instance administration
+	admin := api.Group("/admin", requireAuth, middleware.SessionOnly(), middleware.AdminOnly(adminService))
+	admin.Get("/users", adminHandler.ListUsers)
+	admin.Put("/users/:id", adminHandler.UpdateUser)
+	admin.Delete("/users/:id", adminHandler.DeleteUser)
+	admin.Post("/users/:id/reset-password", adminHandler.ResetPassword)
+	admin.Get("/invites", adminHandler.ListInvites)
+	admin.Post("/invites", adminHandler.CreateInvite)
+	admin.Delete("/invites/:id", adminHandler.RevokeInvite)
 
-	// Protected routes middleware
-	protected := api.Group("", middleware.AuthMiddleware(cfg.JWTSecret))
+	// Protected routes middleware, limited per user
+	protected := api.Group("", requireAuth, middleware.RateLimitByUser(cfg.APIRateLimit, cfg.APIRateWindow))
 
 	// Board routes
 	protected.Get("/boards", boardHandler.List)
 	protected.Post("/boards", boardHandler.Create)
 	protected.Put("/boards/reorder", boardHandler.Reorder)
+	protected.Post("/boards/import", exportHandler.Import)
+	protected.Post("/boards/import/trello", exportHandler.ImportTrello)
 	protected.Get("/boards/:id", boardHandler.Get)
 	protected.Put("/boards/:id", boardHandler.Update)
 	protected.Delete("/boards/:id", boardHandler.Delete)
+	protected.Post("/boards/:id/duplicate", boardHandler.Duplicate)
+	protected.Get("/boards/:id/export", exportHandler.Export)
+	protected.Get("/boards/:id/export.csv", exportHandler.ExportCSV)
+	protected.Get("/boards/:id/export.md", exportHandler.ExportMarkdown)
+	protected.Get("/boards/:id/events", eventHandler.Stream)
+	protected.Get("/boards/:id/activity", activityHandler.ListForBoard)
+
+	// Board member routes
+	protected.Get("/boards/:id/members", memberHandler.List)
+	protected.Post("/boards/:id/members", memberHandler.Add)
+	protected.Put("/boards/:id/members/:userId", memberHandler.UpdateRole)
+	protected.Delete("/boards/:id/members/:userId", memberHandler.Remove)
+
+	// Label routes
+	protected.Get("/boards/:id/labels", labelHandler.List)
+	protected.Post("/boards/:id/labels", labelHandler.Create)
+	protected.Put("/boards/:id/labels/:labelId", labelHandler.Update)
+	protected.Delete("/boards/:id/labels/:labelId", labelHandler.Delete)
+
+	// Template routes
+	protected.Get("/templates", templateHandler.List)
+	protected.Get("/templates/:id", templateHandler.Get)
+	protected.Delete("/templates/:id", templateHandler.Delete)
+	protected.Post("/boards/:id/template", templateHandler.SaveFromBoard)
+
+	// Swimlane routes
+	protected.Get("/boards/:id/swimlanes", swimlaneHandler.List)
+	protected.Post("/boards/:id/swimlanes", swimlaneHandler.Create)
+	protected.Put("/boards/:id/swimlanes/reorder", swimlaneHandler.Reorder) // Must be before /swimlanes/:swimlaneId routes
+	protected.Put("/boards/:id/swimlanes/:swimlaneId", swimlaneHandler.Update)
+	protected.Delete("/boards/:id/swimlanes/:swimlaneId", swimlaneHandler.Delete)
 
 	// Column routes
 	protected.Post("/boards/:boardId/columns", columnHandler.Create)
+	protected.Put("/columns/reorder", columnHandler.Reorder) // Must be before /columns/:id routes
 	protected.Put("/columns/:id", columnHandler.Update)
 	protected.Delete("/columns/:id", columnHandler.Delete)
-	protected.Put("/columns/reorder", columnHandler.Reorder)
 
 	// Card routes
 	protected.Post("/columns/:columnId/cards", cardHandler.Create)
 	protected.Put("/cards/reorder", cardHandler.Reorder) // Must be before /cards/:id routes
+	protected.Get("/cards/overdue", cardHandler.ListOverdue)
+	protected.Get("/cards/due-soon", cardHandler.ListDueSoon)
 	protected.Get("/cards/:id", cardHandler.Get)
 	protected.Put("/cards/:id", cardHandler.Update)
 	protected.Delete("/cards/:id", cardHandler.Delete)
 	protected.Put("/cards/:id/move", cardHandler.Move)
+	protected.Post("/cards/:id/assignees", cardHandler.Assign)
+	protected.Delete("/cards/:id/assignees/:userId", cardHandler.Unassign)
+	protected.Post("/cards/:id/labels/:labelId", labelHandler.Attach)
+	protected.Delete("/cards/:id/labels/:labelId", labelHandler.Detach)
+	protected.Get("/cards/:id/activity", activityHandler.ListForCard)
+
+	// Comment routes
+	protected.Get("/cards/:id/comments", commentHandler.List)
+	protected.Post("/cards/:id/comments", commentHandler.Create)
+	protected.Put("/comments/:id", commentHandler.Update)
+	protected.Delete("/comments/:id", commentHandler.Delete)
+	protected.Get("/comments/:id/history", commentHandler.History)
+
+	// Checklist routes
+	protected.Get("/cards/:id/checklists", checklistHandler.List)
+	protected.Post("/cards/:id/checklists", checklistHandler.Create)
+	protected.Put("/cards/:id/checklists/reorder", checklistHandler.Reorder) // Must be before /checklists/:checklistId routes
+	protected.Put("/cards/:id/checklists/:checklistId", checklistHandler.Update)
+	protected.Delete("/cards/:id/checklists/:checklistId", checklistHandler.Delete)
+	protected.Post("/cards/:id/checklists/:checklistId/items", checklistHandler.AddItem)
+	protected.Put("/cards/:id/checklists/:checklistId/items/reorder", checklistHandler.ReorderItems) // Must be before /items/:itemId routes
+	protected.Put("/cards/:id/checklists/:checklistId/items/:itemId", checklistHandler.UpdateItem)
+	protected.Delete("/cards/:id/checklists/:checklistId/items/:itemId", checklistHandler.DeleteItem)
+
+	// Attachment routes
+	protected.Get("/cards/:id/attachments", attachmentHandler.List)
+	protected.Post("/cards/:id/attachments", attachmentHandler.Upload)
+	protected.Get("/cards/:id/attachments/:attachmentId", attachmentHandler.Download)
+	protected.Delete("/cards/:id/attachments/:attachmentId", attachmentHandler.Delete)
+
+	// Search routes
+	protected.Get("/search", searchHandler.Search)
+
+	// Trash routes
+	protected.Get("/trash", trashHandler.List)
+	protected.Post("/trash/boards/:id/restore", trashHandler.RestoreBoard)
+	protected.Post("/trash/columns/:id/restore", trashHandler.RestoreColumn)
+	protected.Post("/trash/cards/:id/restore", trashHandler.RestoreCard)
+
+	// Current user routes
+	protected.Get("/me/cards", cardHandler.ListAssigned)
 }
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/icl00ud/goban/internal/mail"
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/repository"
+	"github.com/icl00ud/goban/internal/utils"
+)
+
+var (
+	ErrEmailAlreadyVerified = errors.New("email address is already verified")
+	ErrInvalidUserToken     = errors.New("link is invalid, expired or already used")
+)
+
+// How long emailed links stay valid, with the wording used in the emails
+const (
+	verifyEmailTTL     = 48 * time.Hour
+	verifyEmailTTLText = "48 hours"
+	resetPasswordTTL   = time.Hour
+	resetPasswordText  = "1 hour"
+)
+
+// userTokenRetention keeps expired tokens around briefly before pruning
+const userTokenRetention = 7 * 24 * time.Hour
+
+type AccountService struct {
+	userRepo       *repository.UserRepository
+	tokenRepo      *repository.UserTokenRepository
+	sessionService *SessionService
+	mailer         mail.Mailer
+	appURL         string
+}
+
+func NewAccountService(userRepo *repository.UserRepository, tokenRepo *repository.UserTokenRepository, sessionService *SessionService, mailer mail.Mailer, appURL string) *AccountService {
+	return &AccountService{
+		userRepo:       userRepo,
+		tokenRepo:      tokenRepo,
+		sessionService: sessionService,
+		mailer:         mailer,
+		appURL:         appURL,
+	}
+}
+
+// SendVerification emails the user a link to verify their email address.
+// The email is sent in the background so a slow mail server doesn't hold up
+// the request.
+func (s *AccountService) SendVerification(user *models.User) error {
+	link, err := s.issueToken(user, models.TokenPurposeVerifyEmail, verifyEmailTTL, "/verify-email")
+	if err != nil {
+		return err
+	}
+
+	s.send("verify_email", user, link, verifyEmailTTLText)
+	return nil
+}
+
+// ResendVerification emails a new verification link to a user who has not
+// verified their email address yet
+func (s *AccountService) ResendVerification(userID uint) error {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerifiedAt != nil {
+		return ErrEmailAlreadyVerified
+	}
+	return s.SendVerification(user)
+}
+
+// VerifyEmail marks the user's email address as verified with an emailed token
+func (s *AccountService) VerifyEmail(secret string) (*models.User, error) {
+	token, err := s.redeem(models.TokenPurposeVerifyEmail, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if err := s.userRepo.MarkEmailVerified(token.UserID, now); err != nil {
+		return nil, err
+	}
+	if token.User.EmailVerifiedAt == nil {
+		token.User.EmailVerifiedAt = &now
+	}
+	return &token.User, nil
+}
+
+// ForgotPassword emails a password reset link when an active account exists
+// for the address. It reports nothing either way so accounts can't be
+// discovered.
+func (s *AccountService) ForgotPassword(email string) error {
+	user, err := s.userRepo.FindByEmailFold(email)
+	if err != nil || user.DisabledAt != nil {
+		return nil
+	}
+	return s.SendPasswordReset(user)
+}
+
+// SendPasswordReset emails the user a link to choose a new password
+func (s *AccountService) SendPasswordReset(user *models.User) error {
+	link, err := s.issueToken(user, models.TokenPurposeResetPassword, resetPasswordTTL, "/reset-password")
+	if err != nil {
+		return err
+	}
+
+	s.send("reset_password", user, link, resetPasswordText)
+	return nil
+}
+
+// ResetPassword sets a new password with an emailed token. Every session of
+// the user is signed out, a login lockout is lifted, and the email address
+// counts as verified.
+func (s *AccountService) ResetPassword(secret, password string) error {
+	token, err := s.redeem(models.TokenPurposeResetPassword, secret)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return err
+	}
+	if err := s.userRepo.UpdatePassword(token.UserID, hashedPassword); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	if err := s.tokenRepo.InvalidateAll(token.UserID, models.TokenPurposeResetPassword, now); err != nil {
+		return err
+	}
+	if err := s.userRepo.MarkEmailVerified(token.UserID, now); err != nil {
+		return err
+	}
+	if err := s.userRepo.ResetLoginFailures(token.UserID); err != nil {
+		return err
+	}
+	return s.sessionService.RevokeAll(token.UserID)
+}
+
+// issueToken stores a new token for the user and returns the app link that
+// carries it
+func (s *AccountService) issueToken(user *models.User, purpose string, ttl time.Duration, path string) (string, error) {
+	now := time.Now().UTC()
+	if err := s.tokenRepo.DeleteExpiredBefore(now.Add(-userTokenRetention)); err != nil {
+		return "", err
+	}
+
+	secret, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+
+	token := &models.UserToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenHash: hashToken(secret),
+		Email:     user.Email,
+		ExpiresAt: now.Add(ttl),
+	}
+	if err := s.tokenRepo.Create(token); err != nil {
+		return "", err
+	}
+
+	return s.appURL + path + "?" + url.Values{"token": {secret}}.Encode(), nil
+}
+
+// redeem uses up an unexpired token issued to the user's current address
+func (s *AccountService) redeem(purpose, secret string) (*models.UserToken, error) {
+	if secret == "" {
+		return nil, ErrInvalidUserToken
+	}
+
+	token, err := s.tokenRepo.FindByHash(purpose, hashToken(secret))
+	if err != nil || token.User.ID == 0 || token.UsedAt != nil {
+		return nil, ErrInvalidUserToken
+	}
+	if time.Now().After(token.ExpiresAt) || token.Email != token.User.Email {
+		return nil, ErrInvalidUserToken
+	}
+
+	used, err := s.tokenRepo.MarkUsed(token.ID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, ErrInvalidUserToken
+	}
+	return token, nil
+}
+
+// send renders and delivers an email in the background, logging failures
+func (s *AccountService) send(template string, user *models.User, link, expiresIn string) {
+	msg, err := mail.Render(template, user.Email, mail.TemplateData{
+		Name:      user.Name,
+		Email:     user.Email,
+		Link:      link,
+		ExpiresIn: expiresIn,
+	})
+	if err != nil {
+		log.Printf("Failed to render %s email: %v", template, err)
+		return
+	}
+
+	go func() {
+		if err := s.mailer.Send(msg); err != nil {
+			log.Printf("Failed to send %s email to user %d: %v", template, user.ID, err)
+		}
+	}()
+}
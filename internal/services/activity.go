@@ -0,0 +1,118 @@
+package services
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/repository"
+)
+
+// Activity page size bounds
+const (
+	defaultActivityLimit = 50
+	maxActivityLimit     = 100
+)
+
+type ActivityService struct {
+	activityRepo *repository.ActivityRepository
+	cardRepo     *repository.CardRepository
+	boardRepo    *repository.BoardRepository
+}
+
+func NewActivityService(activityRepo *repository.ActivityRepository, cardRepo *repository.CardRepository, boardRepo *repository.BoardRepository) *ActivityService {
+	return &ActivityService{
+		activityRepo: activityRepo,
+		cardRepo:     cardRepo,
+		boardRepo:    boardRepo,
+	}
+}
+
+// ListForBoard retrieves a page of a board's activity, newest first. The
+// returned cursor fetches the next page and is zero on the last page.
+func (s *ActivityService) ListForBoard(boardID, userID, cursor uint, limit int) ([]models.Activity, uint, error) {
+	if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleViewer); err != nil {
+		return nil, 0, err
+	}
+
+	limit = clampActivityLimit(limit)
+	activities, err := s.activityRepo.FindPageByBoardID(boardID, cursor, limit+1)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return paginateActivities(activities, limit)
+}
+
+// ListForCard retrieves a page of a card's activity, newest first. Deleted
+// cards keep their history.
+func (s *ActivityService) ListForCard(cardID, userID, cursor uint, limit int) ([]models.Activity, uint, error) {
+	boardID, err := s.cardRepo.GetBoardIDIncludingDeleted(cardID)
+	if err != nil {
+		return nil, 0, ErrCardNotFound
+	}
+
+	if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleViewer); err != nil {
+		return nil, 0, err
+	}
+
+	limit = clampActivityLimit(limit)
+	activities, err := s.activityRepo.FindPageByEntity(models.EntityCard, cardID, cursor, limit+1)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return paginateActivities(activities, limit)
+}
+
+// paginateActivities trims a page fetched with one extra row and derives the
+// next cursor from it
+func paginateActivities(activities []models.Activity, limit int) ([]models.Activity, uint, error) {
+	if len(activities) <= limit {
+		return activities, 0, nil
+	}
+
+	activities = activities[:limit]
+	return activities, activities[limit-1].ID, nil
+}
+
+func clampActivityLimit(limit int) int {
+	if limit < 1 {
+		return defaultActivityLimit
+	}
+	if limit > maxActivityLimit {
+		return maxActivityLimit
+	}
+	return limit
+}
+
+// recordActivity adds an entry to a board's audit trail. A failure to record
+// must not undo the change itself, so it is logged rather than returned.
+func recordActivity(activityRepo *repository.ActivityRepository, boardID, actorID uint, entityType string, entityID uint, action string, before, after interface{}) {
+	activity := &models.Activity{
+		BoardID:    boardID,
+		UserID:     actorID,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Before:     activitySnapshot(before),
+		After:      activitySnapshot(after),
+	}
+
+	if err := activityRepo.Create(activity); err != nil {
+		log.Printf("Failed to record %s %s activity on board %d: %v", entityType, action, boardID, err)
+	}
+}
+
+// activitySnapshot serializes an entity state, using an empty string for none
+func activitySnapshot(state interface{}) string {
+	if state == nil {
+		return ""
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
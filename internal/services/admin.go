@@ -0,0 +1,236 @@
+package services
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/repository"
+	"github.com/icl00ud/goban/internal/utils"
+)
+
+var (
+	ErrNotAdmin           = errors.New("administrator access required")
+	ErrCannotModifySelf   = errors.New("you cannot disable, demote or delete your own account")
+	ErrInviteNotFound     = errors.New("invite not found")
+	ErrInviteExpiryInPast = errors.New("expiry must be in the future")
+)
+
+type AdminService struct {
+	userRepo       *repository.UserRepository
+	boardRepo      *repository.BoardRepository
+	inviteRepo     *repository.InviteRepository
+	sessionService *SessionService
+	accountService *AccountService
+	appURL         string
+}
+
+func NewAdminService(userRepo *repository.UserRepository, boardRepo *repository.BoardRepository, inviteRepo *repository.InviteRepository, sessionService *SessionService, accountService *AccountService, appURL string) *AdminService {
+	return &AdminService{
+		userRepo:       userRepo,
+		boardRepo:      boardRepo,
+		inviteRepo:     inviteRepo,
+		sessionService: sessionService,
+		accountService: accountService,
+		appURL:         appURL,
+	}
+}
+
+// IsAdmin reports whether the user is an active administrator
+func (s *AdminService) IsAdmin(userID uint) bool {
+	user, err := s.userRepo.FindByID(userID)
+	return err == nil && user.IsAdmin && user.DisabledAt == nil
+}
+
+// ListUsers retrieves every user, optionally filtered by name or email
+func (s *AdminService) ListUsers(query string) ([]models.User, error) {
+	return s.userRepo.FindAll(strings.TrimSpace(query))
+}
+
+// UpdateUser grants or revokes the administrator role and disables or
+// enables a user. Disabling signs the user out everywhere. Administrators
+// can't change their own account, so an instance always keeps one.
+func (s *AdminService) UpdateUser(actorID, userID uint, isAdmin, disabled *bool) (*models.User, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if user.ID == actorID && (isAdmin != nil || disabled != nil) {
+		return nil, ErrCannotModifySelf
+	}
+
+	if isAdmin != nil && *isAdmin != user.IsAdmin {
+		if err := s.userRepo.SetAdmin(user.ID, *isAdmin); err != nil {
+			return nil, err
+		}
+		user.IsAdmin = *isAdmin
+	}
+
+	if disabled != nil && *disabled != (user.DisabledAt != nil) {
+		var disabledAt *time.Time
+		if *disabled {
+			now := time.Now().UTC()
+			disabledAt = &now
+		}
+		if err := s.userRepo.SetDisabled(user.ID, disabledAt); err != nil {
+			return nil, err
+		}
+		if *disabled {
+			if err := s.sessionService.RevokeAll(user.ID); err != nil {
+				return nil, err
+			}
+		}
+		user.DisabledAt = disabledAt
+	}
+
+	return user, nil
+}
+
+// DeleteUser deletes a user. Boards they created go to the trash and are
+// purged with it; their comments and activity stay under their name.
+func (s *AdminService) DeleteUser(actorID, userID uint) error {
+	if userID == actorID {
+		return ErrCannotModifySelf
+	}
+	if _, err := s.userRepo.FindByID(userID); err != nil {
+		return ErrUserNotFound
+	}
+
+	boardIDs, err := s.boardRepo.FindIDsOwnedBy(userID)
+	if err != nil {
+		return err
+	}
+	for _, boardID := range boardIDs {
+		if err := s.boardRepo.Delete(boardID); err != nil {
+			return err
+		}
+	}
+
+	return s.userRepo.Delete(userID, time.Now().UTC())
+}
+
+// ResetPassword sets a new password for a user and signs them out
+// everywhere, or emails them a reset link when password is empty. It reports
+// whether an email was sent.
+func (s *AdminService) ResetPassword(userID uint, password string) (bool, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return false, ErrUserNotFound
+	}
+
+	if password == "" {
+		return true, s.accountService.SendPasswordReset(user)
+	}
+
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return false, err
+	}
+	if err := s.userRepo.UpdatePassword(user.ID, hashedPassword); err != nil {
+		return false, err
+	}
+	if err := s.userRepo.ResetLoginFailures(user.ID); err != nil {
+		return false, err
+	}
+	return false, s.sessionService.RevokeAll(user.ID)
+}
+
+// ListInvites retrieves every invite, newest first
+func (s *AdminService) ListInvites() ([]models.Invite, error) {
+	return s.inviteRepo.FindAll()
+}
+
+// CreateInvite issues an invite, optionally only for one email address. The
+// returned code is only available now; just its hash is stored.
+func (s *AdminService) CreateInvite(actorID uint, email string, expiresAt *time.Time) (*models.Invite, string, error) {
+	actor, err := s.userRepo.FindByID(actorID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if expiresAt != nil {
+		if !expiresAt.After(time.Now()) {
+			return nil, "", ErrInviteExpiryInPast
+		}
+		utc := expiresAt.UTC()
+		expiresAt = &utc
+	}
+
+	code, err := randomToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	invite := &models.Invite{
+		CodeHash:    hashToken(code),
+		Email:       strings.TrimSpace(email),
+		ExpiresAt:   expiresAt,
+		CreatedByID: actor.ID,
+	}
+	if err := s.inviteRepo.Create(invite); err != nil {
+		return nil, "", err
+	}
+	invite.CreatedBy = *actor
+
+	return invite, code, nil
+}
+
+// InviteLink returns the registration link for an invite code
+func (s *AdminService) InviteLink(code string) string {
+	return s.appURL + "/register?" + url.Values{"invite": {code}}.Encode()
+}
+
+// RevokeInvite deletes an invite
+func (s *AdminService) RevokeInvite(inviteID uint) error {
+	if _, err := s.inviteRepo.FindByID(inviteID); err != nil {
+		return ErrInviteNotFound
+	}
+	return s.inviteRepo.Delete(inviteID)
+}
+
+// PromoteByEmail makes an existing user an active administrator. Together
+// with CreateAdmin it sets up an instance from the command line.
+func (s *AdminService) PromoteByEmail(email string) (*models.User, error) {
+	user, err := s.userRepo.FindByEmailFold(email)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	if err := s.userRepo.SetAdmin(user.ID, true); err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.SetDisabled(user.ID, nil); err != nil {
+		return nil, err
+	}
+	user.IsAdmin = true
+	user.DisabledAt = nil
+	return user, nil
+}
+
+// CreateAdmin creates an administrator account, whatever the registration
+// policy
+func (s *AdminService) CreateAdmin(email, name, password string) (*models.User, error) {
+	if s.userRepo.ExistsByEmail(email) {
+		return nil, ErrUserExists
+	}
+
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	user := &models.User{
+		Email:           email,
+		PasswordHash:    hashedPassword,
+		Name:            name,
+		IsAdmin:         true,
+		EmailVerifiedAt: &now,
+	}
+	if err := s.userRepo.Create(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
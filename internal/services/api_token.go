@@ -0,0 +1,144 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/repository"
+)
+
+var (
+	ErrAPITokenNotFound     = errors.New("API token not found")
+	ErrAPITokenNameRequired = errors.New("token name is required")
+	ErrAPITokenNameTooLong  = errors.New("token name must be at most 100 characters")
+	ErrInvalidTokenScope    = errors.New("scope must be read or write")
+	ErrTokenExpiryInPast    = errors.New("expiry must be in the future")
+	ErrInvalidAPIToken      = errors.New("invalid or expired API token")
+)
+
+// APITokenPrefix starts every personal access token so they are easy to
+// recognize in configs and secret scanners
+const APITokenPrefix = "gbn_"
+
+// Token secret size and how much of it is kept in the clear for display
+const (
+	apiTokenBytes         = 32
+	apiTokenDisplayLength = 12
+)
+
+// lastUsedResolution bounds how often a token's last-used time is written, so
+// busy scripts don't cause a write on every request
+const lastUsedResolution = time.Minute
+
+type APITokenService struct {
+	tokenRepo *repository.APITokenRepository
+}
+
+func NewAPITokenService(tokenRepo *repository.APITokenRepository) *APITokenService {
+	return &APITokenService{tokenRepo: tokenRepo}
+}
+
+// List retrieves the user's API tokens, newest first
+func (s *APITokenService) List(userID uint) ([]models.APIToken, error) {
+	return s.tokenRepo.FindAllByUserID(userID)
+}
+
+// Create issues a new API token for the user. The returned secret is only
+// available now; just its hash is stored.
+func (s *APITokenService) Create(userID uint, name, scope string, expiresAt *time.Time) (*models.APIToken, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", ErrAPITokenNameRequired
+	}
+	if utf8.RuneCountInString(name) > 100 {
+		return nil, "", ErrAPITokenNameTooLong
+	}
+	if !models.ValidateTokenScope(scope) {
+		return nil, "", ErrInvalidTokenScope
+	}
+	if expiresAt != nil {
+		if !expiresAt.After(time.Now()) {
+			return nil, "", ErrTokenExpiryInPast
+		}
+		utc := expiresAt.UTC()
+		expiresAt = &utc
+	}
+
+	buf := make([]byte, apiTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, "", err
+	}
+	secret := APITokenPrefix + hex.EncodeToString(buf)
+
+	token := &models.APIToken{
+		Name:      name,
+		TokenHash: hashToken(secret),
+		Prefix:    secret[:apiTokenDisplayLength],
+		Scope:     scope,
+		ExpiresAt: expiresAt,
+		UserID:    userID,
+	}
+	if err := s.tokenRepo.Create(token); err != nil {
+		return nil, "", err
+	}
+
+	return token, secret, nil
+}
+
+// Revoke deletes one of the user's API tokens
+func (s *APITokenService) Revoke(tokenID, userID uint) error {
+	token, err := s.tokenRepo.FindByID(tokenID)
+	if err != nil || token.UserID != userID {
+		return ErrAPITokenNotFound
+	}
+	return s.tokenRepo.Delete(tokenID)
+}
+
+// Authenticate resolves a presented API token to the stored token and its
+// user, recording when it was used
+func (s *APITokenService) Authenticate(secret string) (*models.APIToken, error) {
+	if !strings.HasPrefix(secret, APITokenPrefix) {
+		return nil, ErrInvalidAPIToken
+	}
+
+	token, err := s.tokenRepo.FindByHash(hashToken(secret))
+	if err != nil {
+		return nil, ErrInvalidAPIToken
+	}
+
+	now := time.Now().UTC()
+	if token.Expired(now) || token.User.ID == 0 || token.User.DisabledAt != nil {
+		return nil, ErrInvalidAPIToken
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= lastUsedResolution {
+		if err := s.tokenRepo.TouchLastUsed(token.ID, now); err != nil {
+			log.Printf("Failed to record use of API token %d: %v", token.ID, err)
+		}
+		token.LastUsedAt = &now
+	}
+
+	return token, nil
+}
+
+// StillValid checks that an API token authenticated earlier has not since
+// been revoked or expired and its user has not been disabled, for long-lived
+// requests such as event streams
+func (s *APITokenService) StillValid(tokenID uint) bool {
+	token, err := s.tokenRepo.FindByIDWithUser(tokenID)
+	return err == nil && !token.Expired(time.Now().UTC()) && token.User.ID != 0 && token.User.DisabledAt == nil
+}
+
+// hashToken hashes a token secret for storage and lookup. Secrets are
+// random and long, so a fast hash is enough.
+func hashToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/icl00ud/goban/internal/events"
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/repository"
+	"github.com/icl00ud/goban/internal/storage"
+)
+
+var (
+	ErrAttachmentNotFound      = errors.New("attachment not found")
+	ErrAttachmentEmpty         = errors.New("file is empty")
+	ErrAttachmentTooLarge      = errors.New("file exceeds the maximum upload size")
+	ErrAttachmentTypeForbidden = errors.New("file type is not allowed")
+)
+
+// sniffLength is how many bytes http.DetectContentType looks at
+const sniffLength = 512
+
+type AttachmentService struct {
+	attachmentRepo *repository.AttachmentRepository
+	cardRepo       *repository.CardRepository
+	boardRepo      *repository.BoardRepository
+	store          storage.Storage
+	broker         *events.Broker
+	maxSize        int64
+	allowedTypes   []string
+}
+
+func NewAttachmentService(attachmentRepo *repository.AttachmentRepository, cardRepo *repository.CardRepository, boardRepo *repository.BoardRepository, store storage.Storage, broker *events.Broker, maxSize int64, allowedTypes []string) *AttachmentService {
+	return &AttachmentService{
+		attachmentRepo: attachmentRepo,
+		cardRepo:       cardRepo,
+		boardRepo:      boardRepo,
+		store:          store,
+		broker:         broker,
+		maxSize:        maxSize,
+		allowedTypes:   allowedTypes,
+	}
+}
+
+// List retrieves all attachments of a card
+func (s *AttachmentService) List(cardID, userID uint) ([]models.Attachment, error) {
+	if _, err := s.cardBoard(cardID, userID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	return s.attachmentRepo.FindAllByCardID(cardID)
+}
+
+// Upload stores a file and attaches it to a card. The content type is always
+// detected from the file contents; the client's type is only used to tell
+// apart plain text formats.
+func (s *AttachmentService) Upload(cardID, userID uint, fileName, contentType string, size int64, r io.Reader) (*models.Attachment, error) {
+	boardID, err := s.cardBoard(cardID, userID, models.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	if size <= 0 {
+		return nil, ErrAttachmentEmpty
+	}
+	if size > s.maxSize {
+		return nil, ErrAttachmentTooLarge
+	}
+
+	reader := bufio.NewReaderSize(r, sniffLength)
+	head, _ := reader.Peek(sniffLength)
+	contentType = detectContentType(head, contentType)
+	if !s.typeAllowed(contentType) {
+		return nil, ErrAttachmentTypeForbidden
+	}
+
+	key, err := newStorageKey(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.Put(key, reader, size, contentType); err != nil {
+		return nil, err
+	}
+
+	attachment := &models.Attachment{
+		CardID:      cardID,
+		UserID:      userID,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        size,
+		StorageKey:  key,
+	}
+
+	if err := s.attachmentRepo.Create(attachment); err != nil {
+		s.removeObject(key)
+		return nil, err
+	}
+
+	attachment, err = s.attachmentRepo.FindByID(attachment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	publishEvent(s.broker, events.AttachmentCreated, boardID, userID, attachment)
+	return attachment, nil
+}
+
+// Open retrieves an attachment and opens its contents for download. The
+// caller must close the returned reader.
+func (s *AttachmentService) Open(cardID, attachmentID, userID uint) (*models.Attachment, io.ReadCloser, error) {
+	_, attachment, err := s.cardAttachment(cardID, attachmentID, userID, models.RoleViewer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contents, err := s.store.Get(attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil, ErrAttachmentNotFound
+		}
+		return nil, nil, err
+	}
+
+	return attachment, contents, nil
+}
+
+// Delete removes an attachment and its stored contents
+func (s *AttachmentService) Delete(cardID, attachmentID, userID uint) error {
+	boardID, attachment, err := s.cardAttachment(cardID, attachmentID, userID, models.RoleEditor)
+	if err != nil {
+		return err
+	}
+
+	if err := s.attachmentRepo.Delete(attachment.ID); err != nil {
+		return err
+	}
+	s.removeObject(attachment.StorageKey)
+
+	publishEvent(s.broker, events.AttachmentDeleted, boardID, userID, map[string]uint{"id": attachment.ID, "card_id": cardID})
+	return nil
+}
+
+// typeAllowed checks a content type against the allowed list, which may
+// contain wildcards such as "image/*"
+func (s *AttachmentService) typeAllowed(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, allowed := range s.allowedTypes {
+		if allowed == "*/*" || allowed == mediaType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// textSubtypes are the declared types that may refine contents detected as
+// plain text, which http.DetectContentType cannot tell apart
+var textSubtypes = map[string]bool{
+	"text/csv":         true,
+	"text/markdown":    true,
+	"application/json": true,
+}
+
+// detectContentType sniffs the content type of a file from its first bytes.
+// The declared type is only trusted to refine plain text.
+func detectContentType(head []byte, declared string) string {
+	detected := http.DetectContentType(head)
+	if !strings.HasPrefix(detected, "text/plain") {
+		return detected
+	}
+
+	mediaType, _, err := mime.ParseMediaType(declared)
+	if err != nil || !textSubtypes[mediaType] {
+		return detected
+	}
+	_, params, _ := mime.ParseMediaType(detected)
+	return mime.FormatMediaType(mediaType, params)
+}
+
+// removeObject deletes stored contents whose metadata is gone. Failures only
+// leave an unreferenced object behind, so they are logged rather than returned.
+func (s *AttachmentService) removeObject(key string) {
+	if err := s.store.Delete(key); err != nil {
+		log.Printf("Failed to delete attachment object %s: %v", key, err)
+	}
+}
+
+// cardBoard resolves the board of a card and checks the user's role on it
+func (s *AttachmentService) cardBoard(cardID, userID uint, minRole string) (uint, error) {
+	boardID, err := s.cardRepo.GetColumnBoardID(cardID)
+	if err != nil {
+		return 0, ErrCardNotFound
+	}
+
+	if err := checkBoardRole(s.boardRepo, boardID, userID, minRole); err != nil {
+		return 0, err
+	}
+
+	return boardID, nil
+}
+
+// cardAttachment loads an attachment that belongs to the card after checking
+// the user's role on the card's board
+func (s *AttachmentService) cardAttachment(cardID, attachmentID, userID uint, minRole string) (uint, *models.Attachment, error) {
+	boardID, err := s.cardBoard(cardID, userID, minRole)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	attachment, err := s.attachmentRepo.FindByID(attachmentID)
+	if err != nil || attachment.CardID != cardID {
+		return 0, nil, ErrAttachmentNotFound
+	}
+
+	return boardID, attachment, nil
+}
+
+// newStorageKey generates an unguessable storage key for a card's attachment
+func newStorageKey(cardID uint) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("cards/%d/%s", cardID, hex.EncodeToString(buf)), nil
+}
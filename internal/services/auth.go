@@ -2,6 +2,7 @@ package services
 
 import (
 	"errors"
+	"time"
 
 	"github.com/icl00ud/goban/internal/dto"
 	"github.com/icl00ud/goban/internal/models"
@@ -12,22 +13,63 @@ import (
 var (
 	ErrUserExists       = errors.New("user with this email already exists")
 	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrAccountLocked      = errors.New("too many failed login attempts, try again later")
+	ErrAccountDisabled    = errors.New("this account has been disabled")
 )
 
+// AccountLockedError reports until when an account is locked after repeated
+// failed logins. It matches ErrAccountLocked.
+type AccountLockedError struct {
+	Until time.Time
+}
+
+func (e *AccountLockedError) Error() string {
+	return ErrAccountLocked.Error()
+}
+
+func (e *AccountLockedError) Is(target error) bool {
+	return target == ErrAccountLocked
+}
+
+// checkAccountLock returns an AccountLockedError while the user is locked out
+// after repeated failed logins
+func checkAccountLock(user *models.User, now time.Time) error {
+	if user.LockedUntil != nil && now.Before(*user.LockedUntil) {
+		return &AccountLockedError{Until: *user.LockedUntil}
+	}
+	return nil
+}
+
 type AuthService struct {
-	userRepo  *repository.UserRepository
-	jwtSecret string
+	userRepo        *repository.UserRepository
+	inviteRepo      *repository.InviteRepository
+	registration    RegistrationPolicy
+	maxFailures     int
+	lockoutDuration time.Duration
+	unknownLogins   *unknownLoginFailures
 }
 
-func NewAuthService(userRepo *repository.UserRepository, jwtSecret string) *AuthService {
+func NewAuthService(userRepo *repository.UserRepository, inviteRepo *repository.InviteRepository, registration RegistrationPolicy, maxFailures int, lockoutDuration time.Duration) *AuthService {
 	return &AuthService{
-		userRepo:  userRepo,
-		jwtSecret: jwtSecret,
+		userRepo:        userRepo,
+		inviteRepo:      inviteRepo,
+		registration:    registration,
+		maxFailures:     maxFailures,
+		lockoutDuration: lockoutDuration,
+		unknownLogins:   newUnknownLoginFailures(),
 	}
 }
 
-// Register creates a new user account
+// Register creates a new user account if the registration policy allows it.
+// The first account becomes the instance administrator.
 func (s *AuthService) Register(req *dto.RegisterRequest) (*models.User, error) {
+	// The policy is applied first so a closed instance does not reveal which
+	// addresses have accounts
+	invite, err := s.checkRegistration(req.Email, req.InviteCode)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check if user already exists
 	if s.userRepo.ExistsByEmail(req.Email) {
 		return nil, ErrUserExists
@@ -46,33 +88,74 @@ func (s *AuthService) Register(req *dto.RegisterRequest) (*models.User, error) {
 		Name:         req.Name,
 	}
 
-	if err := s.userRepo.Create(user); err != nil {
+	if invite != nil {
+		redeemed, err := s.inviteRepo.Redeem(invite.ID, user, time.Now().UTC())
+		if err != nil {
+			return nil, err
+		}
+		if !redeemed {
+			return nil, ErrInvalidInvite
+		}
+	} else if err := s.userRepo.Create(user); err != nil {
 		return nil, err
 	}
 
+	promoted, err := s.userRepo.PromoteIfFirst(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	user.IsAdmin = promoted
+
 	return user, nil
 }
 
-// Login authenticates a user and returns a JWT token
-func (s *AuthService) Login(req *dto.LoginRequest) (*models.User, string, error) {
+// Login authenticates a user by email and password. Repeated failures lock
+// the account for a while. Addresses without an account are locked the same
+// way, so a lockout does not reveal whether an account exists.
+func (s *AuthService) Login(req *dto.LoginRequest) (*models.User, error) {
+	now := time.Now().UTC()
+
 	// Find user by email
 	user, err := s.userRepo.FindByEmail(req.Email)
 	if err != nil {
-		return nil, "", ErrInvalidCredentials
+		if err := s.unknownLogins.check(req.Email, now); err != nil {
+			return nil, err
+		}
+		if s.maxFailures > 0 {
+			s.unknownLogins.record(req.Email, s.maxFailures, now.Add(s.lockoutDuration), now)
+		}
+		return nil, ErrInvalidCredentials
+	}
+
+	// Locked accounts are rejected without checking the password
+	if err := checkAccountLock(user, now); err != nil {
+		return nil, err
 	}
 
 	// Verify password
 	if !utils.CheckPassword(req.Password, user.PasswordHash) {
-		return nil, "", ErrInvalidCredentials
+		if s.maxFailures > 0 {
+			if err := s.userRepo.RecordLoginFailure(user.ID, s.maxFailures, now.Add(s.lockoutDuration)); err != nil {
+				return nil, err
+			}
+		}
+		return nil, ErrInvalidCredentials
 	}
 
-	// Generate JWT token
-	token, err := utils.GenerateToken(user.ID, user.Email, s.jwtSecret)
-	if err != nil {
-		return nil, "", err
+	// Disabled accounts are only revealed to someone who knows the password
+	if user.DisabledAt != nil {
+		return nil, ErrAccountDisabled
 	}
 
-	return user, token, nil
+	// Users with two-factor authentication keep their failures until the
+	// login challenge is completed, as wrong codes count too
+	if !user.TOTPEnabled && (user.FailedLogins > 0 || user.LockedUntil != nil) {
+		if err := s.userRepo.ResetLoginFailures(user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return user, nil
 }
 
 // GetUserByID retrieves a user by their ID
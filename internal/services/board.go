@@ -1,38 +1,58 @@
 package services
 
 import (
+	"encoding/json"
 	"errors"
 
+	"github.com/icl00ud/goban/internal/events"
 	"github.com/icl00ud/goban/internal/models"
 	"github.com/icl00ud/goban/internal/repository"
 )
 
 var (
-	ErrBoardNotFound  = errors.New("board not found")
-	ErrNotBoardOwner  = errors.New("you don't have access to this board")
+	ErrBoardNotFound       = errors.New("board not found")
+	ErrNotBoardOwner       = errors.New("you don't have access to this board")
+	ErrInsufficientRole    = errors.New("your role on this board doesn't allow this action")
+	ErrInvalidWIPLimitMode = errors.New("WIP limit mode must be warn or reject")
+
+	ErrDuplicateChecklistsWithoutCards = errors.New("checklists can only be copied together with cards")
 )
 
+// maxBoardNameLength matches the size of the board name column
+const maxBoardNameLength = 100
+
 // Default columns for new boards
 var defaultColumns = []string{"To Do", "In Progress", "Done"}
 
 type BoardService struct {
-	boardRepo  *repository.BoardRepository
-	columnRepo *repository.ColumnRepository
+	boardRepo    *repository.BoardRepository
+	columnRepo   *repository.ColumnRepository
+	templateRepo *repository.TemplateRepository
+	activityRepo *repository.ActivityRepository
+	broker       *events.Broker
 }
 
-func NewBoardService(boardRepo *repository.BoardRepository, columnRepo *repository.ColumnRepository) *BoardService {
+func NewBoardService(boardRepo *repository.BoardRepository, columnRepo *repository.ColumnRepository, templateRepo *repository.TemplateRepository, activityRepo *repository.ActivityRepository, broker *events.Broker) *BoardService {
 	return &BoardService{
-		boardRepo:  boardRepo,
-		columnRepo: columnRepo,
+		boardRepo:    boardRepo,
+		columnRepo:   columnRepo,
+		templateRepo: templateRepo,
+		activityRepo: activityRepo,
+		broker:       broker,
 	}
 }
 
-// Create creates a new board with default columns
-func (s *BoardService) Create(userID uint, name, description, color string) (*models.Board, error) {
+// Create creates a new board laid out like the template, or with the default
+// columns when templateID is zero
+func (s *BoardService) Create(userID uint, name, description, color string, templateID uint) (*models.Board, error) {
 	if color == "" {
 		color = "#3b82f6"
 	}
 
+	if templateID != 0 {
+		return s.createFromTemplate(userID, name, description, color, templateID)
+	}
+
 	// Get next position
 	maxPos := s.boardRepo.GetMaxPosition(userID)
 
@@ -61,42 +81,140 @@ func (s *BoardService) Create(userID uint, name, description, color string) (*mo
 	if err := s.columnRepo.CreateBatch(columns); err != nil {
 		// Board was created but columns failed - log this but don't fail
 		// The user can add columns manually
+		recordActivity(s.activityRepo, board.ID, userID, models.EntityBoard, board.ID, models.ActionCreated, nil, board)
+		publishEvent(s.broker, events.BoardCreated, board.ID, userID, board)
 		return board, nil
 	}
 
 	// Reload board with columns
-	return s.boardRepo.FindByIDWithDetails(board.ID)
+	board, err := s.boardRepo.FindByIDWithDetails(board.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	recordActivity(s.activityRepo, board.ID, userID, models.EntityBoard, board.ID, models.ActionCreated, nil, board)
+	publishEvent(s.broker, events.BoardCreated, board.ID, userID, board)
+	return board, nil
+}
+
+// createFromTemplate creates a board with a template's columns, labels,
+// swimlanes and sample cards
+func (s *BoardService) createFromTemplate(userID uint, name, description, color string, templateID uint) (*models.Board, error) {
+	template, err := findUsableTemplate(s.templateRepo, templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var structure models.TemplateStructure
+	if err := json.Unmarshal([]byte(template.Structure), &structure); err != nil {
+		return nil, err
+	}
+
+	board := &models.Board{
+		Name:         name,
+		Description:  description,
+		Color:        color,
+		Position:     s.boardRepo.GetMaxPosition(userID) + 1,
+		WIPLimitMode: structure.WIPLimitMode,
+		UserID:       userID,
+	}
+	if !models.ValidateWIPLimitMode(board.WIPLimitMode) {
+		board.WIPLimitMode = models.WIPLimitWarn
+	}
+
+	if err := s.templateRepo.CreateBoard(board, &structure); err != nil {
+		return nil, err
+	}
+
+	board, err = s.boardRepo.FindByIDWithDetails(board.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	recordActivity(s.activityRepo, board.ID, userID, models.EntityBoard, board.ID, models.ActionCreated, nil, board)
+	publishEvent(s.broker, events.BoardCreated, board.ID, userID, board)
+	return board, nil
 }
 
-// GetByID retrieves a board by ID with ownership check
-func (s *BoardService) GetByID(boardID, userID uint) (*models.Board, error) {
-	if !s.boardRepo.BelongsToUser(boardID, userID) {
-		return nil, ErrNotBoardOwner
+// Duplicate copies a board the user can view into a new board the user owns.
+// Columns, WIP limits and swimlanes are always copied; opts selects cards,
+// labels and checklists. An empty name uses "Copy of" the source name.
+func (s *BoardService) Duplicate(boardID, userID uint, name string, opts repository.DuplicateOptions) (*models.Board, error) {
+	if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleViewer); err != nil {
+		return nil, err
 	}
 
-	board, err := s.boardRepo.FindByIDWithDetails(boardID)
+	source, err := s.boardRepo.FindByID(boardID)
 	if err != nil {
 		return nil, ErrBoardNotFound
 	}
 
+	if opts.Checklists && !opts.Cards {
+		return nil, ErrDuplicateChecklistsWithoutCards
+	}
+
+	if name == "" {
+		name = truncateRunes("Copy of "+source.Name, maxBoardNameLength)
+	}
+
+	board := &models.Board{
+		Name:         name,
+		Description:  source.Description,
+		Color:        source.Color,
+		Position:     s.boardRepo.GetMaxPosition(userID) + 1,
+		WIPLimitMode: source.WIPLimitMode,
+		UserID:       userID,
+	}
+
+	if err := s.boardRepo.Duplicate(boardID, board, opts); err != nil {
+		return nil, err
+	}
+
+	board, err = s.boardRepo.FindByIDWithDetails(board.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	recordActivity(s.activityRepo, board.ID, userID, models.EntityBoard, board.ID, models.ActionCreated, nil, board)
+	publishEvent(s.broker, events.BoardCreated, board.ID, userID, board)
 	return board, nil
 }
 
-// GetAllByUser retrieves all boards for a user with columns and cards
+// GetByID retrieves a board by ID with access check. When labelIDs is not
+// empty, only cards carrying at least one of those labels are included.
+func (s *BoardService) GetByID(boardID, userID uint, labelIDs []uint) (*models.Board, error) {
+	if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	board, err := s.boardRepo.FindByIDWithFilteredDetails(boardID, repository.CardFilter{LabelIDs: labelIDs})
+	if err != nil {
+		return nil, ErrBoardNotFound
+	}
+
+	return board, nil
+}
+
+// GetAllByUser retrieves all boards the user owns or is a member of with columns and cards
 func (s *BoardService) GetAllByUser(userID uint) ([]models.Board, error) {
 	return s.boardRepo.FindAllByUserIDWithDetails(userID)
 }
 
-// Update updates a board with ownership check
-func (s *BoardService) Update(boardID, userID uint, name, description, color string) (*models.Board, error) {
-	if !s.boardRepo.BelongsToUser(boardID, userID) {
-		return nil, ErrNotBoardOwner
+// Update updates a board, restricted to board owners
+func (s *BoardService) Update(boardID, userID uint, name, description, color, wipLimitMode string) (*models.Board, error) {
+	if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	if wipLimitMode != "" && !models.ValidateWIPLimitMode(wipLimitMode) {
+		return nil, ErrInvalidWIPLimitMode
 	}
 
 	board, err := s.boardRepo.FindByID(boardID)
 	if err != nil {
 		return nil, ErrBoardNotFound
 	}
+	before := *board
 
 	if name != "" {
 		board.Name = name
@@ -105,36 +223,97 @@ func (s *BoardService) Update(boardID, userID uint, name, description, color str
 	if color != "" {
 		board.Color = color
 	}
+	if wipLimitMode != "" {
+		board.WIPLimitMode = wipLimitMode
+	}
 
 	if err := s.boardRepo.Update(board); err != nil {
 		return nil, err
 	}
 
+	recordActivity(s.activityRepo, board.ID, userID, models.EntityBoard, board.ID, models.ActionUpdated, before, board)
+	publishEvent(s.broker, events.BoardUpdated, board.ID, userID, board)
 	return board, nil
 }
 
-// Delete deletes a board with ownership check
+// Delete deletes a board, restricted to board owners
 func (s *BoardService) Delete(boardID, userID uint) error {
-	if !s.boardRepo.BelongsToUser(boardID, userID) {
-		return ErrNotBoardOwner
+	if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleOwner); err != nil {
+		return err
 	}
 
-	return s.boardRepo.Delete(boardID)
+	board, err := s.boardRepo.FindByID(boardID)
+	if err != nil {
+		return ErrBoardNotFound
+	}
+
+	if err := s.boardRepo.Delete(boardID); err != nil {
+		return err
+	}
+
+	recordActivity(s.activityRepo, boardID, userID, models.EntityBoard, boardID, models.ActionDeleted, board, nil)
+	publishEvent(s.broker, events.BoardDeleted, boardID, userID, map[string]uint{"id": boardID})
+	return nil
+}
+
+// CheckAccess verifies that a user can at least view a board
+func (s *BoardService) CheckAccess(boardID, userID uint) error {
+	return checkBoardRole(s.boardRepo, boardID, userID, models.RoleViewer)
 }
 
 // CheckOwnership verifies if a user owns a board
 func (s *BoardService) CheckOwnership(boardID, userID uint) bool {
-	return s.boardRepo.BelongsToUser(boardID, userID)
+	return s.boardRepo.GetUserRole(boardID, userID) == models.RoleOwner
 }
 
-// Reorder updates the position of boards
+// Reorder stores the order the user arranged their boards in. Each member
+// orders shared boards for themselves.
 func (s *BoardService) Reorder(userID uint, boardIDs []uint) error {
-	// Verify all boards belong to the user
+	// Verify the user can access all boards
 	for _, boardID := range boardIDs {
-		if !s.boardRepo.BelongsToUser(boardID, userID) {
-			return ErrNotBoardOwner
+		if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleViewer); err != nil {
+			return err
 		}
 	}
 
-	return s.boardRepo.UpdatePositions(userID, boardIDs)
+	owned, err := s.boardRepo.UpdatePositions(userID, boardIDs)
+	if err != nil {
+		return err
+	}
+
+	// Positions of shared boards are the member's own and not board activity
+	positions := make(map[uint]int, len(boardIDs))
+	for i, boardID := range boardIDs {
+		positions[boardID] = i
+	}
+	for _, boardID := range owned {
+		recordActivity(s.activityRepo, boardID, userID, models.EntityBoard, boardID, models.ActionReordered, nil, map[string]int{"position": positions[boardID]})
+	}
+	return nil
+}
+
+// publishEvent notifies board subscribers about a change
+func publishEvent(broker *events.Broker, eventType string, boardID, actorID uint, data interface{}) {
+	broker.Publish(events.Event{Type: eventType, BoardID: boardID, ActorID: actorID, Data: data})
+}
+
+// checkBoardRole verifies that a user has at least minRole on a board
+func checkBoardRole(boardRepo *repository.BoardRepository, boardID, userID uint, minRole string) error {
+	role := boardRepo.GetUserRole(boardID, userID)
+	if role == "" {
+		return ErrNotBoardOwner
+	}
+	if !models.RoleAtLeast(role, minRole) {
+		return ErrInsufficientRole
+	}
+	return nil
+}
+
+// truncateRunes shortens s to at most n characters
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
 }
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/repository"
+)
+
+var (
+	ErrMemberNotFound     = errors.New("member not found")
+	ErrMemberExists       = errors.New("user is already a member of this board")
+	ErrInvalidRole        = errors.New("role must be one of: owner, editor, viewer")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrBoardCreatorMember = errors.New("the board creator cannot be removed or demoted")
+)
+
+type MemberService struct {
+	memberRepo *repository.BoardMemberRepository
+	boardRepo  *repository.BoardRepository
+	userRepo   *repository.UserRepository
+}
+
+func NewMemberService(memberRepo *repository.BoardMemberRepository, boardRepo *repository.BoardRepository, userRepo *repository.UserRepository) *MemberService {
+	return &MemberService{
+		memberRepo: memberRepo,
+		boardRepo:  boardRepo,
+		userRepo:   userRepo,
+	}
+}
+
+// List returns all members of a board, starting with the board creator
+func (s *MemberService) List(boardID, userID uint) ([]models.BoardMember, error) {
+	if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	board, err := s.boardRepo.FindByID(boardID)
+	if err != nil {
+		return nil, ErrBoardNotFound
+	}
+
+	creator, err := s.userRepo.FindByID(board.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.memberRepo.FindAllByBoardID(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []models.BoardMember{{
+		BoardID:   boardID,
+		UserID:    creator.ID,
+		Role:      models.RoleOwner,
+		CreatedAt: board.CreatedAt,
+		User:      *creator,
+	}}
+	return append(result, members...), nil
+}
+
+// Add invites a user to a board by email, restricted to board owners
+func (s *MemberService) Add(boardID, userID uint, email, role string) (*models.BoardMember, error) {
+	if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	if role == "" {
+		role = models.RoleViewer
+	}
+	if !models.ValidateRole(role) {
+		return nil, ErrInvalidRole
+	}
+
+	user, err := s.userRepo.FindByEmail(email)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	if s.boardRepo.GetUserRole(boardID, user.ID) != "" {
+		return nil, ErrMemberExists
+	}
+
+	member := &models.BoardMember{
+		BoardID: boardID,
+		UserID:  user.ID,
+		Role:    role,
+	}
+
+	if err := s.memberRepo.Create(member); err != nil {
+		return nil, err
+	}
+
+	member.User = *user
+	return member, nil
+}
+
+// UpdateRole changes a member's role, restricted to board owners
+func (s *MemberService) UpdateRole(boardID, userID, memberUserID uint, role string) (*models.BoardMember, error) {
+	if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	if !models.ValidateRole(role) {
+		return nil, ErrInvalidRole
+	}
+
+	if s.isCreator(boardID, memberUserID) {
+		return nil, ErrBoardCreatorMember
+	}
+
+	member, err := s.memberRepo.FindByBoardAndUser(boardID, memberUserID)
+	if err != nil {
+		return nil, ErrMemberNotFound
+	}
+
+	member.Role = role
+	if err := s.memberRepo.Update(member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// Remove removes a member from a board. Owners can remove anyone except the
+// board creator; any member can remove themselves.
+func (s *MemberService) Remove(boardID, userID, memberUserID uint) error {
+	if userID != memberUserID {
+		if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleOwner); err != nil {
+			return err
+		}
+	}
+
+	if s.isCreator(boardID, memberUserID) {
+		return ErrBoardCreatorMember
+	}
+
+	if _, err := s.memberRepo.FindByBoardAndUser(boardID, memberUserID); err != nil {
+		return ErrMemberNotFound
+	}
+
+	return s.memberRepo.Delete(boardID, memberUserID)
+}
+
+// isCreator checks whether the user created the board
+func (s *MemberService) isCreator(boardID, userID uint) bool {
+	board, err := s.boardRepo.FindByID(boardID)
+	if err != nil {
+		return false
+	}
+	return board.UserID == userID
+}
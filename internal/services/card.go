@@ -2,42 +2,60 @@ package services
 
 import (
 	"errors"
+	"time"
 
+	"github.com/icl00ud/goban/internal/dto"
+	"github.com/icl00ud/goban/internal/events"
 	"github.com/icl00ud/goban/internal/models"
 	"github.com/icl00ud/goban/internal/repository"
 )
 
 var (
-	ErrCardNotFound = errors.New("card not found")
+	ErrCardNotFound     = errors.New("card not found")
+	ErrAssigneeNoAccess = errors.New("assignee doesn't have access to this board")
+	ErrInvalidDateRange = errors.New("start date must be before due date")
+	ErrWIPLimitExceeded = errors.New("column WIP limit reached")
 )
 
+// maxDueWithinDays bounds the look-ahead window for upcoming deadlines
+const maxDueWithinDays = 365
+
 type CardService struct {
-	cardRepo   *repository.CardRepository
-	columnRepo *repository.ColumnRepository
-	boardRepo  *repository.BoardRepository
+	cardRepo     *repository.CardRepository
+	columnRepo   *repository.ColumnRepository
+	boardRepo    *repository.BoardRepository
+	userRepo     *repository.UserRepository
+	swimlaneRepo *repository.SwimlaneRepository
+	activityRepo *repository.ActivityRepository
+	broker       *events.Broker
 }
 
-func NewCardService(cardRepo *repository.CardRepository, columnRepo *repository.ColumnRepository, boardRepo *repository.BoardRepository) *CardService {
+func NewCardService(cardRepo *repository.CardRepository, columnRepo *repository.ColumnRepository, boardRepo *repository.BoardRepository, userRepo *repository.UserRepository, swimlaneRepo *repository.SwimlaneRepository, activityRepo *repository.ActivityRepository, broker *events.Broker) *CardService {
 	return &CardService{
-		cardRepo:   cardRepo,
-		columnRepo: columnRepo,
-		boardRepo:  boardRepo,
+		cardRepo:     cardRepo,
+		columnRepo:   columnRepo,
+		boardRepo:    boardRepo,
+		userRepo:     userRepo,
+		swimlaneRepo: swimlaneRepo,
+		activityRepo: activityRepo,
+		broker:       broker,
 	}
 }
 
 // Create creates a new card at the end of the column
-func (s *CardService) Create(columnID, userID uint, title, description, priority string) (*models.Card, error) {
-	// Get column to check board ownership
+func (s *CardService) Create(columnID, userID uint, req *dto.CreateCardRequest) (*models.Card, error) {
+	// Get column to check board access
 	column, err := s.columnRepo.FindByID(columnID)
 	if err != nil {
 		return nil, ErrColumnNotFound
 	}
 
-	if !s.boardRepo.BelongsToUser(column.BoardID, userID) {
-		return nil, ErrNotBoardOwner
+	if err := checkBoardRole(s.boardRepo, column.BoardID, userID, models.RoleEditor); err != nil {
+		return nil, err
 	}
 
 	// Validate priority
+	priority := req.Priority
 	if priority == "" {
 		priority = models.PriorityMedium
 	}
@@ -45,119 +63,164 @@ func (s *CardService) Create(columnID, userID uint, title, description, priority
 		priority = models.PriorityMedium
 	}
 
+	if !validDateRange(req.StartAt, req.DueAt) {
+		return nil, ErrInvalidDateRange
+	}
+
+	if err := checkCardSwimlane(s.swimlaneRepo, column.BoardID, req.SwimlaneID); err != nil {
+		return nil, err
+	}
+
+	exceeded, err := checkWIPLimit(s.cardRepo, s.boardRepo, column)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get max position and add to end
 	maxPos := s.cardRepo.GetMaxPosition(columnID)
 
 	card := &models.Card{
-		Title:       title,
-		Description: description,
+		Title:       req.Title,
+		Description: req.Description,
 		Priority:    priority,
 		Position:    maxPos + 1,
 		ColumnID:    columnID,
+		SwimlaneID:  req.SwimlaneID,
+		StartAt:     toUTC(req.StartAt),
+		DueAt:       toUTC(req.DueAt),
 	}
 
 	if err := s.cardRepo.Create(card); err != nil {
 		return nil, err
 	}
+	card.WIPExceeded = exceeded
 
+	recordActivity(s.activityRepo, column.BoardID, userID, models.EntityCard, card.ID, models.ActionCreated, nil, card)
+	publishEvent(s.broker, events.CardCreated, column.BoardID, userID, card)
 	return card, nil
 }
 
-// GetByID retrieves a card by ID with ownership check
+// GetByID retrieves a card by ID with access check
 func (s *CardService) GetByID(cardID, userID uint) (*models.Card, error) {
-	card, err := s.cardRepo.FindByID(cardID)
+	card, err := s.cardRepo.FindByIDWithDetails(cardID)
 	if err != nil {
 		return nil, ErrCardNotFound
 	}
 
-	// Get column to check board ownership
+	// Get column to check board access
 	column, err := s.columnRepo.FindByID(card.ColumnID)
 	if err != nil {
 		return nil, ErrColumnNotFound
 	}
 
-	if !s.boardRepo.BelongsToUser(column.BoardID, userID) {
-		return nil, ErrNotBoardOwner
+	if err := checkBoardRole(s.boardRepo, column.BoardID, userID, models.RoleViewer); err != nil {
+		return nil, err
 	}
 
 	return card, nil
 }
 
-// Update updates a card with ownership check
-func (s *CardService) Update(cardID, userID uint, title, description, priority string) (*models.Card, error) {
-	card, err := s.cardRepo.FindByID(cardID)
+// Update updates a card with access check
+func (s *CardService) Update(cardID, userID uint, req *dto.UpdateCardRequest) (*models.Card, error) {
+	card, err := s.cardRepo.FindByIDWithDetails(cardID)
 	if err != nil {
 		return nil, ErrCardNotFound
 	}
 
-	// Get column to check board ownership
+	// Get column to check board access
 	column, err := s.columnRepo.FindByID(card.ColumnID)
 	if err != nil {
 		return nil, ErrColumnNotFound
 	}
 
-	if !s.boardRepo.BelongsToUser(column.BoardID, userID) {
-		return nil, ErrNotBoardOwner
+	if err := checkBoardRole(s.boardRepo, column.BoardID, userID, models.RoleEditor); err != nil {
+		return nil, err
 	}
+	before := *card
 
-	if title != "" {
-		card.Title = title
+	if req.Title != "" {
+		card.Title = req.Title
+	}
+	card.Description = req.Description
+	if req.Priority != "" && models.ValidatePriority(req.Priority) {
+		card.Priority = req.Priority
+	}
+	if req.StartAt.Set {
+		card.StartAt = toUTC(req.StartAt.Value)
 	}
-	card.Description = description
-	if priority != "" && models.ValidatePriority(priority) {
-		card.Priority = priority
+	if req.DueAt.Set {
+		card.DueAt = toUTC(req.DueAt.Value)
+	}
+
+	if !validDateRange(card.StartAt, card.DueAt) {
+		return nil, ErrInvalidDateRange
 	}
 
 	if err := s.cardRepo.Update(card); err != nil {
 		return nil, err
 	}
 
+	recordActivity(s.activityRepo, column.BoardID, userID, models.EntityCard, card.ID, models.ActionUpdated, before, card)
+	publishEvent(s.broker, events.CardUpdated, column.BoardID, userID, card)
 	return card, nil
 }
 
-// Delete deletes a card with ownership check
+// Delete deletes a card with access check
 func (s *CardService) Delete(cardID, userID uint) error {
 	card, err := s.cardRepo.FindByID(cardID)
 	if err != nil {
 		return ErrCardNotFound
 	}
 
-	// Get column to check board ownership
+	// Get column to check board access
 	column, err := s.columnRepo.FindByID(card.ColumnID)
 	if err != nil {
 		return ErrColumnNotFound
 	}
 
-	if !s.boardRepo.BelongsToUser(column.BoardID, userID) {
-		return ErrNotBoardOwner
+	if err := checkBoardRole(s.boardRepo, column.BoardID, userID, models.RoleEditor); err != nil {
+		return err
 	}
 
-	return s.cardRepo.Delete(cardID)
+	if err := s.cardRepo.Delete(cardID); err != nil {
+		return err
+	}
+
+	recordActivity(s.activityRepo, column.BoardID, userID, models.EntityCard, cardID, models.ActionDeleted, card, nil)
+	publishEvent(s.broker, events.CardDeleted, column.BoardID, userID, map[string]uint{"id": cardID, "column_id": card.ColumnID})
+	return nil
 }
 
-// Move moves a card to a different column at a specific position
-func (s *CardService) Move(cardID, userID, targetColumnID uint, position int) (*models.Card, error) {
+// Move moves a card to a column and swimlane at a specific position. A zero
+// target column keeps the card in its column; the swimlane is left untouched
+// when not set and cleared when set to nil.
+func (s *CardService) Move(cardID, userID, targetColumnID uint, swimlaneID dto.Optional[uint], position int) (*models.Card, error) {
 	card, err := s.cardRepo.FindByID(cardID)
 	if err != nil {
 		return nil, ErrCardNotFound
 	}
+	before := *card
+
+	if targetColumnID == 0 {
+		targetColumnID = card.ColumnID
+	}
 
-	// Check ownership of source column
+	// Check access to source column
 	sourceColumn, err := s.columnRepo.FindByID(card.ColumnID)
 	if err != nil {
 		return nil, ErrColumnNotFound
 	}
-	if !s.boardRepo.BelongsToUser(sourceColumn.BoardID, userID) {
-		return nil, ErrNotBoardOwner
+	if err := checkBoardRole(s.boardRepo, sourceColumn.BoardID, userID, models.RoleEditor); err != nil {
+		return nil, err
 	}
 
-	// Check ownership of target column
+	// Check access to target column
 	targetColumn, err := s.columnRepo.FindByID(targetColumnID)
 	if err != nil {
 		return nil, ErrColumnNotFound
 	}
-	if !s.boardRepo.BelongsToUser(targetColumn.BoardID, userID) {
-		return nil, ErrNotBoardOwner
+	if err := checkBoardRole(s.boardRepo, targetColumn.BoardID, userID, models.RoleEditor); err != nil {
+		return nil, err
 	}
 
 	// Ensure both columns belong to the same board
@@ -165,25 +228,70 @@ func (s *CardService) Move(cardID, userID, targetColumnID uint, position int) (*
 		return nil, errors.New("cannot move card between different boards")
 	}
 
+	targetSwimlaneID := card.SwimlaneID
+	if swimlaneID.Set {
+		if err := checkCardSwimlane(s.swimlaneRepo, targetColumn.BoardID, swimlaneID.Value); err != nil {
+			return nil, err
+		}
+		targetSwimlaneID = swimlaneID.Value
+	}
+
+	// Moving within a column never changes its card count
+	exceeded := false
+	if targetColumn.ID != sourceColumn.ID {
+		if exceeded, err = checkWIPLimit(s.cardRepo, s.boardRepo, targetColumn); err != nil {
+			return nil, err
+		}
+	}
+
 	// Move the card
-	if err := s.cardRepo.MoveCard(cardID, targetColumnID, position); err != nil {
+	if err := s.cardRepo.MoveCard(cardID, targetColumnID, targetSwimlaneID, position); err != nil {
 		return nil, err
 	}
 
 	// Return updated card
-	return s.cardRepo.FindByID(cardID)
+	card, err = s.cardRepo.FindByIDWithDetails(cardID)
+	if err != nil {
+		return nil, err
+	}
+	card.WIPExceeded = exceeded
+
+	recordActivity(s.activityRepo, targetColumn.BoardID, userID, models.EntityCard, cardID, models.ActionMoved, before, card)
+	publishEvent(s.broker, events.CardMoved, targetColumn.BoardID, userID, map[string]interface{}{
+		"card":               card,
+		"source_column_id":   sourceColumn.ID,
+		"source_swimlane_id": before.SwimlaneID,
+	})
+	return card, nil
+}
+
+// checkWIPLimit reports whether adding a card to the column takes it over its
+// WIP limit. Boards in reject mode refuse the card instead.
+func checkWIPLimit(cardRepo *repository.CardRepository, boardRepo *repository.BoardRepository, column *models.Column) (bool, error) {
+	if column.WIPLimit == nil || cardRepo.CountByColumn(column.ID) < *column.WIPLimit {
+		return false, nil
+	}
+
+	board, err := boardRepo.FindByID(column.BoardID)
+	if err != nil {
+		return false, ErrBoardNotFound
+	}
+	if board.WIPLimitMode == models.WIPLimitReject {
+		return false, ErrWIPLimitExceeded
+	}
+	return true, nil
 }
 
 // Reorder reorders cards within a column
 func (s *CardService) Reorder(columnID, userID uint, cardIDs []uint) error {
-	// Get column to check board ownership
+	// Get column to check board access
 	column, err := s.columnRepo.FindByID(columnID)
 	if err != nil {
 		return ErrColumnNotFound
 	}
 
-	if !s.boardRepo.BelongsToUser(column.BoardID, userID) {
-		return ErrNotBoardOwner
+	if err := checkBoardRole(s.boardRepo, column.BoardID, userID, models.RoleEditor); err != nil {
+		return err
 	}
 
 	// Build positions array
@@ -192,5 +300,152 @@ func (s *CardService) Reorder(columnID, userID uint, cardIDs []uint) error {
 		positions[i] = i
 	}
 
-	return s.cardRepo.UpdatePositions(cardIDs, positions)
+	if err := s.cardRepo.UpdatePositions(columnID, cardIDs, positions); err != nil {
+		return err
+	}
+
+	recordActivity(s.activityRepo, column.BoardID, userID, models.EntityColumn, columnID, models.ActionReordered, nil, map[string][]uint{"card_ids": cardIDs})
+	publishEvent(s.broker, events.CardsReordered, column.BoardID, userID, map[string]interface{}{
+		"column_id": columnID,
+		"card_ids":  cardIDs,
+	})
+	return nil
+}
+
+// Assign assigns a user with access to the board to a card
+func (s *CardService) Assign(cardID, userID, assigneeID uint) (*models.Card, error) {
+	return s.changeAssignee(cardID, userID, assigneeID, models.ActionAssigned, s.cardRepo.AddAssignee)
+}
+
+// Unassign removes a user from a card's assignees
+func (s *CardService) Unassign(cardID, userID, assigneeID uint) (*models.Card, error) {
+	return s.changeAssignee(cardID, userID, assigneeID, models.ActionUnassigned, s.cardRepo.RemoveAssignee)
+}
+
+// changeAssignee applies an assignee change to a card after access checks
+func (s *CardService) changeAssignee(cardID, userID, assigneeID uint, action string, apply func(*models.Card, *models.User) error) (*models.Card, error) {
+	card, err := s.cardRepo.FindByID(cardID)
+	if err != nil {
+		return nil, ErrCardNotFound
+	}
+
+	// Get column to check board access
+	column, err := s.columnRepo.FindByID(card.ColumnID)
+	if err != nil {
+		return nil, ErrColumnNotFound
+	}
+
+	if err := checkBoardRole(s.boardRepo, column.BoardID, userID, models.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	assignee, err := s.userRepo.FindByID(assigneeID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	// Only people who can see the board may be assigned to its cards
+	if s.boardRepo.GetUserRole(column.BoardID, assignee.ID) == "" {
+		return nil, ErrAssigneeNoAccess
+	}
+
+	if err := apply(card, assignee); err != nil {
+		return nil, err
+	}
+
+	card, err = s.cardRepo.FindByIDWithDetails(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	recordActivity(s.activityRepo, column.BoardID, userID, models.EntityCard, cardID, action, nil, map[string]interface{}{
+		"user_id": assignee.ID,
+		"name":    assignee.Name,
+	})
+	publishEvent(s.broker, events.CardUpdated, column.BoardID, userID, card)
+	return card, nil
+}
+
+// GetAssignedToUser retrieves the cards assigned to a user grouped by board and column
+func (s *CardService) GetAssignedToUser(userID uint) ([]models.Board, error) {
+	cards, err := s.cardRepo.FindAllAssignedToUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return groupCardsByBoard(cards), nil
+}
+
+// GetOverdue retrieves cards past their due date on all boards the user can access
+func (s *CardService) GetOverdue(userID uint) ([]models.Board, error) {
+	now := time.Now().UTC()
+	cards, err := s.cardRepo.FindAllDueBetween(userID, nil, &now)
+	if err != nil {
+		return nil, err
+	}
+
+	return groupCardsByBoard(cards), nil
+}
+
+// GetDueWithin retrieves cards due in the next given number of days on all
+// boards the user can access
+func (s *CardService) GetDueWithin(userID uint, days int) ([]models.Board, error) {
+	if days < 1 {
+		days = 1
+	}
+	if days > maxDueWithinDays {
+		days = maxDueWithinDays
+	}
+
+	now := time.Now().UTC()
+	until := now.AddDate(0, 0, days)
+	cards, err := s.cardRepo.FindAllDueBetween(userID, &now, &until)
+	if err != nil {
+		return nil, err
+	}
+
+	return groupCardsByBoard(cards), nil
+}
+
+// groupCardsByBoard nests cards loaded with their column and board into
+// boards and columns. Cards must be ordered by board and then column.
+func groupCardsByBoard(cards []models.Card) []models.Board {
+	boards := []models.Board{}
+	for _, card := range cards {
+		column := card.Column
+		board := column.Board
+
+		if len(boards) == 0 || boards[len(boards)-1].ID != board.ID {
+			board.Columns = []models.Column{}
+			boards = append(boards, board)
+		}
+		current := &boards[len(boards)-1]
+
+		if len(current.Columns) == 0 || current.Columns[len(current.Columns)-1].ID != column.ID {
+			column.Board = models.Board{}
+			column.Cards = []models.Card{}
+			current.Columns = append(current.Columns, column)
+		}
+		currentColumn := &current.Columns[len(current.Columns)-1]
+
+		card.Column = models.Column{}
+		currentColumn.Cards = append(currentColumn.Cards, card)
+	}
+
+	return boards
+}
+
+// validDateRange checks that a card does not start after it is due
+func validDateRange(startAt, dueAt *time.Time) bool {
+	return startAt == nil || dueAt == nil || !startAt.After(*dueAt)
+}
+
+// toUTC normalizes a timestamp so that dates compare consistently in every
+// database driver
+func toUTC(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
 }
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/icl00ud/goban/internal/dto"
+	"github.com/icl00ud/goban/internal/events"
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/repository"
+)
+
+var (
+	ErrChecklistNotFound     = errors.New("checklist not found")
+	ErrChecklistItemNotFound = errors.New("checklist item not found")
+)
+
+type ChecklistService struct {
+	checklistRepo *repository.ChecklistRepository
+	cardRepo      *repository.CardRepository
+	boardRepo     *repository.BoardRepository
+	broker        *events.Broker
+}
+
+func NewChecklistService(checklistRepo *repository.ChecklistRepository, cardRepo *repository.CardRepository, boardRepo *repository.BoardRepository, broker *events.Broker) *ChecklistService {
+	return &ChecklistService{
+		checklistRepo: checklistRepo,
+		cardRepo:      cardRepo,
+		boardRepo:     boardRepo,
+		broker:        broker,
+	}
+}
+
+// List retrieves all checklists of a card with their items
+func (s *ChecklistService) List(cardID, userID uint) ([]models.Checklist, error) {
+	if _, err := s.cardBoard(cardID, userID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	return s.checklistRepo.FindAllByCardID(cardID)
+}
+
+// Create adds a checklist at the end of a card
+func (s *ChecklistService) Create(cardID, userID uint, title string) (*models.Checklist, error) {
+	boardID, err := s.cardBoard(cardID, userID, models.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	checklist := &models.Checklist{
+		Title:    title,
+		Position: s.checklistRepo.GetMaxPosition(cardID) + 1,
+		CardID:   cardID,
+	}
+
+	if err := s.checklistRepo.Create(checklist); err != nil {
+		return nil, err
+	}
+
+	publishEvent(s.broker, events.ChecklistCreated, boardID, userID, checklist)
+	return checklist, nil
+}
+
+// Update renames a checklist
+func (s *ChecklistService) Update(cardID, checklistID, userID uint, title string) (*models.Checklist, error) {
+	boardID, checklist, err := s.cardChecklist(cardID, checklistID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if title != "" {
+		checklist.Title = title
+	}
+
+	if err := s.checklistRepo.Update(checklist); err != nil {
+		return nil, err
+	}
+
+	publishEvent(s.broker, events.ChecklistUpdated, boardID, userID, checklist)
+	return checklist, nil
+}
+
+// Delete deletes a checklist with all its items
+func (s *ChecklistService) Delete(cardID, checklistID, userID uint) error {
+	boardID, _, err := s.cardChecklist(cardID, checklistID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checklistRepo.Delete(checklistID); err != nil {
+		return err
+	}
+
+	publishEvent(s.broker, events.ChecklistDeleted, boardID, userID, map[string]uint{"id": checklistID, "card_id": cardID})
+	return nil
+}
+
+// Reorder reorders the checklists of a card
+func (s *ChecklistService) Reorder(cardID, userID uint, checklistIDs []uint) ([]models.Checklist, error) {
+	boardID, err := s.cardBoard(cardID, userID, models.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checklistRepo.UpdatePositions(cardID, checklistIDs); err != nil {
+		return nil, err
+	}
+
+	checklists, err := s.checklistRepo.FindAllByCardID(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	publishEvent(s.broker, events.ChecklistsReordered, boardID, userID, map[string]interface{}{
+		"card_id":       cardID,
+		"checklist_ids": checklistIDs,
+	})
+	return checklists, nil
+}
+
+// AddItem appends an item to a checklist
+func (s *ChecklistService) AddItem(cardID, checklistID, userID uint, text string) (*models.Checklist, error) {
+	boardID, checklist, err := s.cardChecklist(cardID, checklistID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &models.ChecklistItem{
+		Text:        text,
+		Position:    s.checklistRepo.GetMaxItemPosition(checklist.ID) + 1,
+		ChecklistID: checklist.ID,
+	}
+
+	if err := s.checklistRepo.CreateItem(item); err != nil {
+		return nil, err
+	}
+
+	return s.reloadChecklist(boardID, checklistID, userID)
+}
+
+// UpdateItem edits an item's text or checks/unchecks it
+func (s *ChecklistService) UpdateItem(cardID, checklistID, itemID, userID uint, req *dto.UpdateChecklistItemRequest) (*models.Checklist, error) {
+	boardID, item, err := s.checklistItem(cardID, checklistID, itemID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Text != "" {
+		item.Text = req.Text
+	}
+	if req.Done != nil {
+		item.Done = *req.Done
+	}
+
+	if err := s.checklistRepo.UpdateItem(item); err != nil {
+		return nil, err
+	}
+
+	return s.reloadChecklist(boardID, checklistID, userID)
+}
+
+// DeleteItem removes an item from a checklist
+func (s *ChecklistService) DeleteItem(cardID, checklistID, itemID, userID uint) (*models.Checklist, error) {
+	boardID, _, err := s.checklistItem(cardID, checklistID, itemID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checklistRepo.DeleteItem(itemID); err != nil {
+		return nil, err
+	}
+
+	return s.reloadChecklist(boardID, checklistID, userID)
+}
+
+// ReorderItems reorders the items of a checklist
+func (s *ChecklistService) ReorderItems(cardID, checklistID, userID uint, itemIDs []uint) (*models.Checklist, error) {
+	boardID, _, err := s.cardChecklist(cardID, checklistID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checklistRepo.UpdateItemPositions(checklistID, itemIDs); err != nil {
+		return nil, err
+	}
+
+	return s.reloadChecklist(boardID, checklistID, userID)
+}
+
+// reloadChecklist loads a checklist after an item change and notifies subscribers
+func (s *ChecklistService) reloadChecklist(boardID, checklistID, userID uint) (*models.Checklist, error) {
+	checklist, err := s.checklistRepo.FindByID(checklistID)
+	if err != nil {
+		return nil, err
+	}
+
+	publishEvent(s.broker, events.ChecklistUpdated, boardID, userID, checklist)
+	return checklist, nil
+}
+
+// cardChecklist checks editor access and loads a checklist of the card
+func (s *ChecklistService) cardChecklist(cardID, checklistID, userID uint) (uint, *models.Checklist, error) {
+	boardID, err := s.cardBoard(cardID, userID, models.RoleEditor)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	checklist, err := s.checklistRepo.FindByID(checklistID)
+	if err != nil || checklist.CardID != cardID {
+		return 0, nil, ErrChecklistNotFound
+	}
+
+	return boardID, checklist, nil
+}
+
+// checklistItem checks editor access and loads an item of the card's checklist
+func (s *ChecklistService) checklistItem(cardID, checklistID, itemID, userID uint) (uint, *models.ChecklistItem, error) {
+	boardID, checklist, err := s.cardChecklist(cardID, checklistID, userID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	item, err := s.checklistRepo.FindItemByID(itemID)
+	if err != nil || item.ChecklistID != checklist.ID {
+		return 0, nil, ErrChecklistItemNotFound
+	}
+
+	return boardID, item, nil
+}
+
+// cardBoard checks the user's role on the card's board and returns the board ID
+func (s *ChecklistService) cardBoard(cardID, userID uint, minRole string) (uint, error) {
+	boardID, err := s.cardRepo.GetColumnBoardID(cardID)
+	if err != nil {
+		return 0, ErrCardNotFound
+	}
+
+	if err := checkBoardRole(s.boardRepo, boardID, userID, minRole); err != nil {
+		return 0, err
+	}
+
+	return boardID, nil
+}
@@ -3,31 +3,38 @@ package services
 import (
 	"errors"
 
+	"github.com/icl00ud/goban/internal/dto"
+	"github.com/icl00ud/goban/internal/events"
 	"github.com/icl00ud/goban/internal/models"
 	"github.com/icl00ud/goban/internal/repository"
 )
 
 var (
-	ErrColumnNotFound = errors.New("column not found")
+	ErrColumnNotFound  = errors.New("column not found")
+	ErrInvalidWIPLimit = errors.New("WIP limit must be at least 1")
 )
 
 type ColumnService struct {
-	columnRepo *repository.ColumnRepository
-	boardRepo  *repository.BoardRepository
+	columnRepo   *repository.ColumnRepository
+	boardRepo    *repository.BoardRepository
+	activityRepo *repository.ActivityRepository
+	broker       *events.Broker
 }
 
-func NewColumnService(columnRepo *repository.ColumnRepository, boardRepo *repository.BoardRepository) *ColumnService {
+func NewColumnService(columnRepo *repository.ColumnRepository, boardRepo *repository.BoardRepository, activityRepo *repository.ActivityRepository, broker *events.Broker) *ColumnService {
 	return &ColumnService{
-		columnRepo: columnRepo,
-		boardRepo:  boardRepo,
+		columnRepo:   columnRepo,
+		boardRepo:    boardRepo,
+		activityRepo: activityRepo,
+		broker:       broker,
 	}
 }
 
 // Create creates a new column at the end of the board
 func (s *ColumnService) Create(boardID, userID uint, title string) (*models.Column, error) {
-	// Check board ownership
-	if !s.boardRepo.BelongsToUser(boardID, userID) {
-		return nil, ErrNotBoardOwner
+	// Check board access
+	if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleEditor); err != nil {
+		return nil, err
 	}
 
 	// Get max position and add to end
@@ -43,67 +50,85 @@ func (s *ColumnService) Create(boardID, userID uint, title string) (*models.Colu
 		return nil, err
 	}
 
+	recordActivity(s.activityRepo, boardID, userID, models.EntityColumn, column.ID, models.ActionCreated, nil, column)
+	publishEvent(s.broker, events.ColumnCreated, boardID, userID, column)
 	return column, nil
 }
 
-// GetByID retrieves a column by ID with ownership check
+// GetByID retrieves a column by ID with access check
 func (s *ColumnService) GetByID(columnID, userID uint) (*models.Column, error) {
 	column, err := s.columnRepo.FindByIDWithCards(columnID)
 	if err != nil {
 		return nil, ErrColumnNotFound
 	}
 
-	// Check board ownership
-	if !s.boardRepo.BelongsToUser(column.BoardID, userID) {
-		return nil, ErrNotBoardOwner
+	// Check board access
+	if err := checkBoardRole(s.boardRepo, column.BoardID, userID, models.RoleViewer); err != nil {
+		return nil, err
 	}
 
 	return column, nil
 }
 
-// Update updates a column with ownership check
-func (s *ColumnService) Update(columnID, userID uint, title string) (*models.Column, error) {
+// Update updates a column with access check. The WIP limit is left untouched
+// when not set and removed when set to nil.
+func (s *ColumnService) Update(columnID, userID uint, title string, wipLimit dto.Optional[int]) (*models.Column, error) {
 	column, err := s.columnRepo.FindByID(columnID)
 	if err != nil {
 		return nil, ErrColumnNotFound
 	}
 
-	// Check board ownership
-	if !s.boardRepo.BelongsToUser(column.BoardID, userID) {
-		return nil, ErrNotBoardOwner
+	// Check board access
+	if err := checkBoardRole(s.boardRepo, column.BoardID, userID, models.RoleEditor); err != nil {
+		return nil, err
 	}
+	before := *column
 
 	if title != "" {
 		column.Title = title
 	}
+	if wipLimit.Set {
+		if wipLimit.Value != nil && *wipLimit.Value < 1 {
+			return nil, ErrInvalidWIPLimit
+		}
+		column.WIPLimit = wipLimit.Value
+	}
 
 	if err := s.columnRepo.Update(column); err != nil {
 		return nil, err
 	}
 
+	recordActivity(s.activityRepo, column.BoardID, userID, models.EntityColumn, column.ID, models.ActionUpdated, before, column)
+	publishEvent(s.broker, events.ColumnUpdated, column.BoardID, userID, column)
 	return column, nil
 }
 
-// Delete deletes a column with ownership check
+// Delete deletes a column with access check
 func (s *ColumnService) Delete(columnID, userID uint) error {
 	column, err := s.columnRepo.FindByID(columnID)
 	if err != nil {
 		return ErrColumnNotFound
 	}
 
-	// Check board ownership
-	if !s.boardRepo.BelongsToUser(column.BoardID, userID) {
-		return ErrNotBoardOwner
+	// Check board access
+	if err := checkBoardRole(s.boardRepo, column.BoardID, userID, models.RoleEditor); err != nil {
+		return err
 	}
 
-	return s.columnRepo.Delete(columnID)
+	if err := s.columnRepo.Delete(columnID); err != nil {
+		return err
+	}
+
+	recordActivity(s.activityRepo, column.BoardID, userID, models.EntityColumn, columnID, models.ActionDeleted, column, nil)
+	publishEvent(s.broker, events.ColumnDeleted, column.BoardID, userID, map[string]uint{"id": columnID})
+	return nil
 }
 
 // Reorder reorders columns based on the provided order
 func (s *ColumnService) Reorder(boardID, userID uint, columnIDs []uint) error {
-	// Check board ownership
-	if !s.boardRepo.BelongsToUser(boardID, userID) {
-		return ErrNotBoardOwner
+	// Check board access
+	if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleEditor); err != nil {
+		return err
 	}
 
 	// Build positions array
@@ -112,7 +137,13 @@ func (s *ColumnService) Reorder(boardID, userID uint, columnIDs []uint) error {
 		positions[i] = i
 	}
 
-	return s.columnRepo.UpdatePositions(columnIDs, positions)
+	if err := s.columnRepo.UpdatePositions(boardID, columnIDs, positions); err != nil {
+		return err
+	}
+
+	recordActivity(s.activityRepo, boardID, userID, models.EntityBoard, boardID, models.ActionReordered, nil, map[string][]uint{"column_ids": columnIDs})
+	publishEvent(s.broker, events.ColumnsReordered, boardID, userID, map[string][]uint{"column_ids": columnIDs})
+	return nil
 }
 
 // GetBoardIDForColumn returns the board ID for a column
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/icl00ud/goban/internal/events"
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/repository"
+)
+
+var (
+	ErrCommentNotFound  = errors.New("comment not found")
+	ErrNotCommentAuthor = errors.New("only the author can edit this comment")
+	ErrCommentEmpty     = errors.New("comment body is required")
+	ErrCommentTooLong   = errors.New("comment body must be at most 10000 characters")
+)
+
+// maxCommentLength bounds the Markdown body of a comment
+const maxCommentLength = 10000
+
+type CommentService struct {
+	commentRepo *repository.CommentRepository
+	cardRepo    *repository.CardRepository
+	boardRepo   *repository.BoardRepository
+	broker      *events.Broker
+}
+
+func NewCommentService(commentRepo *repository.CommentRepository, cardRepo *repository.CardRepository, boardRepo *repository.BoardRepository, broker *events.Broker) *CommentService {
+	return &CommentService{
+		commentRepo: commentRepo,
+		cardRepo:    cardRepo,
+		boardRepo:   boardRepo,
+		broker:      broker,
+	}
+}
+
+// List retrieves all comments of a card
+func (s *CommentService) List(cardID, userID uint) ([]models.Comment, error) {
+	if _, err := s.cardBoard(cardID, userID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	return s.commentRepo.FindAllByCardID(cardID)
+}
+
+// Create adds a Markdown comment to a card
+func (s *CommentService) Create(cardID, userID uint, body string) (*models.Comment, error) {
+	boardID, err := s.cardBoard(cardID, userID, models.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateCommentBody(body); err != nil {
+		return nil, err
+	}
+
+	comment := &models.Comment{
+		Body:   body,
+		CardID: cardID,
+		UserID: userID,
+	}
+
+	if err := s.commentRepo.Create(comment); err != nil {
+		return nil, err
+	}
+
+	comment, err = s.commentRepo.FindByID(comment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	publishEvent(s.broker, events.CommentCreated, boardID, userID, comment)
+	return comment, nil
+}
+
+// Update edits a comment, restricted to its author. The previous body is kept
+// as a revision.
+func (s *CommentService) Update(commentID, userID uint, body string) (*models.Comment, error) {
+	comment, err := s.commentRepo.FindByID(commentID)
+	if err != nil {
+		return nil, ErrCommentNotFound
+	}
+
+	boardID, err := s.cardBoard(comment.CardID, userID, models.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.UserID != userID {
+		return nil, ErrNotCommentAuthor
+	}
+
+	if err := validateCommentBody(body); err != nil {
+		return nil, err
+	}
+
+	revision := &models.CommentRevision{
+		CommentID: comment.ID,
+		Action:    models.RevisionEdited,
+		Body:      comment.Body,
+		UserID:    userID,
+	}
+
+	now := time.Now()
+	comment.Body = body
+	comment.EditedAt = &now
+
+	if err := s.commentRepo.UpdateWithRevision(comment, revision); err != nil {
+		return nil, err
+	}
+
+	publishEvent(s.broker, events.CommentUpdated, boardID, userID, comment)
+	return comment, nil
+}
+
+// Delete removes a comment. Authors can delete their own comments and board
+// owners can moderate any comment on their boards.
+func (s *CommentService) Delete(commentID, userID uint) error {
+	comment, err := s.commentRepo.FindByID(commentID)
+	if err != nil {
+		return ErrCommentNotFound
+	}
+
+	minRole := models.RoleEditor
+	if comment.UserID != userID {
+		minRole = models.RoleOwner
+	}
+
+	boardID, err := s.cardBoard(comment.CardID, userID, minRole)
+	if err != nil {
+		return err
+	}
+
+	revision := &models.CommentRevision{
+		CommentID: comment.ID,
+		Action:    models.RevisionDeleted,
+		Body:      comment.Body,
+		UserID:    userID,
+	}
+
+	if err := s.commentRepo.DeleteWithRevision(comment, revision); err != nil {
+		return err
+	}
+
+	publishEvent(s.broker, events.CommentDeleted, boardID, userID, map[string]uint{"id": comment.ID, "card_id": comment.CardID})
+	return nil
+}
+
+// History retrieves the edit and delete history of a comment. The history of
+// a deleted comment is only shown to its author and board owners.
+func (s *CommentService) History(commentID, userID uint) (*models.Comment, []models.CommentRevision, error) {
+	comment, err := s.commentRepo.FindByIDUnscoped(commentID)
+	if err != nil {
+		return nil, nil, ErrCommentNotFound
+	}
+
+	deleted := comment.DeletedAt.Valid
+	minRole := models.RoleViewer
+	if deleted && comment.UserID != userID {
+		minRole = models.RoleOwner
+	}
+
+	if _, err := s.cardBoard(comment.CardID, userID, minRole); err != nil {
+		if deleted && errors.Is(err, ErrInsufficientRole) {
+			return nil, nil, ErrCommentNotFound
+		}
+		return nil, nil, err
+	}
+
+	revisions, err := s.commentRepo.FindRevisions(commentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return comment, revisions, nil
+}
+
+// cardBoard checks the user's role on the card's board and returns the board ID
+func (s *CommentService) cardBoard(cardID, userID uint, minRole string) (uint, error) {
+	boardID, err := s.cardRepo.GetColumnBoardID(cardID)
+	if err != nil {
+		return 0, ErrCardNotFound
+	}
+
+	if err := checkBoardRole(s.boardRepo, boardID, userID, minRole); err != nil {
+		return 0, err
+	}
+
+	return boardID, nil
+}
+
+// validateCommentBody checks the Markdown body of a comment
+func validateCommentBody(body string) error {
+	if body == "" {
+		return ErrCommentEmpty
+	}
+	if len([]rune(body)) > maxCommentLength {
+		return ErrCommentTooLong
+	}
+	return nil
+}
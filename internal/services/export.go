@@ -0,0 +1,382 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/icl00ud/goban/internal/dto"
+	"github.com/icl00ud/goban/internal/events"
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/repository"
+)
+
+var (
+	ErrUnsupportedExportVersion = fmt.Errorf("unsupported export version; expected version %d", dto.BoardExportVersion)
+	ErrInvalidImport            = errors.New("invalid board import")
+)
+
+type ExportService struct {
+	boardRepo     *repository.BoardRepository
+	labelRepo     *repository.LabelRepository
+	checklistRepo *repository.ChecklistRepository
+	commentRepo   *repository.CommentRepository
+	userRepo      *repository.UserRepository
+	activityRepo  *repository.ActivityRepository
+	broker        *events.Broker
+}
+
+func NewExportService(boardRepo *repository.BoardRepository, labelRepo *repository.LabelRepository, checklistRepo *repository.ChecklistRepository, commentRepo *repository.CommentRepository, userRepo *repository.UserRepository, activityRepo *repository.ActivityRepository, broker *events.Broker) *ExportService {
+	return &ExportService{
+		boardRepo:     boardRepo,
+		labelRepo:     labelRepo,
+		checklistRepo: checklistRepo,
+		commentRepo:   commentRepo,
+		userRepo:      userRepo,
+		activityRepo:  activityRepo,
+		broker:        broker,
+	}
+}
+
+// Export builds an export document of a board with its labels, swimlanes,
+// columns and cards, including card assignees, checklists and comments
+func (s *ExportService) Export(boardID, userID uint) (*dto.BoardExport, error) {
+	if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	board, err := s.boardRepo.FindByIDWithDetails(boardID)
+	if err != nil {
+		return nil, ErrBoardNotFound
+	}
+
+	labels, err := s.labelRepo.FindAllByBoardID(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &dto.BoardExport{
+		Version:    dto.BoardExportVersion,
+		ExportedAt: time.Now().UTC(),
+		Board: dto.ExportBoardData{
+			Name:         board.Name,
+			Description:  board.Description,
+			Color:        board.Color,
+			WIPLimitMode: board.WIPLimitMode,
+			Labels:       make([]dto.ExportLabel, len(labels)),
+			Swimlanes:    make([]dto.ExportSwimlane, len(board.Swimlanes)),
+			Columns:      make([]dto.ExportColumn, len(board.Columns)),
+		},
+	}
+
+	for i, label := range labels {
+		doc.Board.Labels[i] = dto.ExportLabel{ID: label.ID, Name: label.Name, Color: label.Color}
+	}
+	for i, swimlane := range board.Swimlanes {
+		doc.Board.Swimlanes[i] = dto.ExportSwimlane{ID: swimlane.ID, Title: swimlane.Title, Collapsed: swimlane.Collapsed}
+	}
+
+	for i, column := range board.Columns {
+		exported := dto.ExportColumn{
+			Title:    column.Title,
+			WIPLimit: column.WIPLimit,
+			Cards:    make([]dto.ExportCard, len(column.Cards)),
+		}
+		for j := range column.Cards {
+			card, err := s.exportCard(&column.Cards[j])
+			if err != nil {
+				return nil, err
+			}
+			exported.Cards[j] = *card
+		}
+		doc.Board.Columns[i] = exported
+	}
+
+	return doc, nil
+}
+
+// exportCard converts a card loaded with its assignees and labels, adding its
+// checklists and comments
+func (s *ExportService) exportCard(card *models.Card) (*dto.ExportCard, error) {
+	checklists, err := s.checklistRepo.FindAllByCardID(card.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.commentRepo.FindAllByCardID(card.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	exported := &dto.ExportCard{
+		Title:       card.Title,
+		Description: card.Description,
+		Priority:    card.Priority,
+		StartAt:     card.StartAt,
+		DueAt:       card.DueAt,
+		SwimlaneID:  card.SwimlaneID,
+		LabelIDs:    make([]uint, len(card.Labels)),
+		Assignees:   make([]string, len(card.Assignees)),
+		Checklists:  make([]dto.ExportChecklist, len(checklists)),
+		Comments:    make([]dto.ExportComment, len(comments)),
+		CreatedAt:   card.CreatedAt,
+	}
+
+	for i, label := range card.Labels {
+		exported.LabelIDs[i] = label.ID
+	}
+	for i, assignee := range card.Assignees {
+		exported.Assignees[i] = assignee.Email
+	}
+	for i, checklist := range checklists {
+		exported.Checklists[i] = dto.ExportChecklist{
+			Title: checklist.Title,
+			Items: make([]dto.ExportChecklistItem, len(checklist.Items)),
+		}
+		for j, item := range checklist.Items {
+			exported.Checklists[i].Items[j] = dto.ExportChecklistItem{Text: item.Text, Done: item.Done}
+		}
+	}
+	for i, comment := range comments {
+		exported.Comments[i] = dto.ExportComment{
+			Author:    comment.User.Email,
+			Body:      comment.Body,
+			CreatedAt: comment.CreatedAt,
+			EditedAt:  comment.EditedAt,
+		}
+	}
+
+	return exported, nil
+}
+
+// Import recreates an exported board as a new board owned by the user. The
+// user is the only member of the new board, so only assignments to the user
+// are kept, and comments are attributed to the user with their original dates.
+func (s *ExportService) Import(userID uint, doc *dto.BoardExport) (*models.Board, error) {
+	if doc.Version != dto.BoardExportVersion {
+		return nil, ErrUnsupportedExportVersion
+	}
+
+	if err := validateImport(&doc.Board); err != nil {
+		return nil, err
+	}
+
+	return s.importBoard(userID, &doc.Board)
+}
+
+// importBoard creates a new board of the user from validated board data
+func (s *ExportService) importBoard(userID uint, boardData *dto.ExportBoardData) (*models.Board, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	data := buildBoardImport(boardData, user)
+	data.Board.Position = s.boardRepo.GetMaxPosition(userID) + 1
+	data.Board.UserID = userID
+
+	if err := s.boardRepo.Import(data); err != nil {
+		return nil, err
+	}
+
+	board, err := s.boardRepo.FindByIDWithDetails(data.Board.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	recordActivity(s.activityRepo, board.ID, userID, models.EntityBoard, board.ID, models.ActionCreated, nil, board)
+	publishEvent(s.broker, events.BoardCreated, board.ID, userID, board)
+	return board, nil
+}
+
+// buildBoardImport converts a validated export document into the models to create
+func buildBoardImport(data *dto.ExportBoardData, user *models.User) *repository.BoardImport {
+	color := data.Color
+	if color == "" {
+		color = "#3b82f6"
+	}
+	mode := data.WIPLimitMode
+	if mode == "" {
+		mode = models.WIPLimitWarn
+	}
+
+	result := &repository.BoardImport{
+		Board: &models.Board{
+			Name:         strings.TrimSpace(data.Name),
+			Description:  data.Description,
+			Color:        color,
+			WIPLimitMode: mode,
+		},
+		Labels:    make([]models.Label, len(data.Labels)),
+		Swimlanes: make([]models.Swimlane, len(data.Swimlanes)),
+		Columns:   make([]repository.ColumnImport, len(data.Columns)),
+	}
+
+	for i, label := range data.Labels {
+		result.Labels[i] = models.Label{ID: label.ID, Name: label.Name, Color: label.Color}
+	}
+	for i, swimlane := range data.Swimlanes {
+		result.Swimlanes[i] = models.Swimlane{ID: swimlane.ID, Title: swimlane.Title, Collapsed: swimlane.Collapsed}
+	}
+
+	for i, column := range data.Columns {
+		result.Columns[i] = repository.ColumnImport{
+			Column: models.Column{Title: column.Title, WIPLimit: column.WIPLimit},
+			Cards:  make([]repository.CardImport, len(column.Cards)),
+		}
+
+		for j, card := range column.Cards {
+			priority := card.Priority
+			if priority == "" {
+				priority = models.PriorityMedium
+			}
+
+			imported := repository.CardImport{
+				Card: models.Card{
+					Title:       card.Title,
+					Description: card.Description,
+					Priority:    priority,
+					StartAt:     toUTC(card.StartAt),
+					DueAt:       toUTC(card.DueAt),
+					SwimlaneID:  card.SwimlaneID,
+					CreatedAt:   card.CreatedAt,
+				},
+				LabelIDs:   card.LabelIDs,
+				Checklists: make([]models.Checklist, len(card.Checklists)),
+				Comments:   make([]models.Comment, len(card.Comments)),
+			}
+
+			for _, email := range card.Assignees {
+				if strings.EqualFold(email, user.Email) {
+					imported.AssigneeIDs = []uint{user.ID}
+				}
+			}
+			for k, checklist := range card.Checklists {
+				imported.Checklists[k] = models.Checklist{Title: checklist.Title}
+				for _, item := range checklist.Items {
+					imported.Checklists[k].Items = append(imported.Checklists[k].Items, models.ChecklistItem{Text: item.Text, Done: item.Done})
+				}
+			}
+			for k, comment := range card.Comments {
+				imported.Comments[k] = models.Comment{
+					Body:      comment.Body,
+					UserID:    user.ID,
+					CreatedAt: comment.CreatedAt,
+					EditedAt:  comment.EditedAt,
+				}
+			}
+
+			result.Columns[i].Cards[j] = imported
+		}
+	}
+
+	return result
+}
+
+// validateImport checks an export document against the rules the API
+// enforces when boards are built by hand, and that every label and swimlane
+// reference resolves within the document
+func validateImport(data *dto.ExportBoardData) error {
+	invalid := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%w: %s", ErrInvalidImport, fmt.Sprintf(format, args...))
+	}
+
+	name := strings.TrimSpace(data.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxBoardNameLength {
+		return invalid("board name is required and must be at most %d characters", maxBoardNameLength)
+	}
+	if data.Color != "" && !models.ValidateColor(data.Color) {
+		return invalid("board color must be a hex value like #3b82f6")
+	}
+	if data.WIPLimitMode != "" && !models.ValidateWIPLimitMode(data.WIPLimitMode) {
+		return invalid("board WIP limit mode must be warn or reject")
+	}
+
+	labelIDs := make(map[uint]bool, len(data.Labels))
+	for _, label := range data.Labels {
+		if label.Name == "" || !models.ValidateColor(label.Color) {
+			return invalid("label %d needs a name and a hex color", label.ID)
+		}
+		if utf8.RuneCountInString(label.Name) > maxLabelNameLength {
+			return invalid("label %d: name must be at most %d characters", label.ID, maxLabelNameLength)
+		}
+		if labelIDs[label.ID] {
+			return invalid("duplicate label ID %d", label.ID)
+		}
+		labelIDs[label.ID] = true
+	}
+
+	swimlaneIDs := make(map[uint]bool, len(data.Swimlanes))
+	for _, swimlane := range data.Swimlanes {
+		if swimlane.Title == "" {
+			return invalid("swimlane %d needs a title", swimlane.ID)
+		}
+		if swimlaneIDs[swimlane.ID] {
+			return invalid("duplicate swimlane ID %d", swimlane.ID)
+		}
+		swimlaneIDs[swimlane.ID] = true
+	}
+
+	for i, column := range data.Columns {
+		if column.Title == "" {
+			return invalid("column %d needs a title", i+1)
+		}
+		if utf8.RuneCountInString(column.Title) > maxColumnTitleLength {
+			return invalid("column %d: title must be at most %d characters", i+1, maxColumnTitleLength)
+		}
+		if column.WIPLimit != nil && *column.WIPLimit < 1 {
+			return invalid("column %q: %s", column.Title, ErrInvalidWIPLimit)
+		}
+
+		for _, card := range column.Cards {
+			if card.Title == "" {
+				return invalid("a card in column %q has no title", column.Title)
+			}
+			if utf8.RuneCountInString(card.Title) > maxCardTitleLength {
+				return invalid("a card title in column %q is longer than %d characters", column.Title, maxCardTitleLength)
+			}
+			if card.Priority != "" && !models.ValidatePriority(card.Priority) {
+				return invalid("card %q has unknown priority %q", card.Title, card.Priority)
+			}
+			if !validDateRange(card.StartAt, card.DueAt) {
+				return invalid("card %q: %s", card.Title, ErrInvalidDateRange)
+			}
+			if card.SwimlaneID != nil && !swimlaneIDs[*card.SwimlaneID] {
+				return invalid("card %q refers to unknown swimlane %d", card.Title, *card.SwimlaneID)
+			}
+			for _, id := range card.LabelIDs {
+				if !labelIDs[id] {
+					return invalid("card %q refers to unknown label %d", card.Title, id)
+				}
+			}
+			for _, checklist := range card.Checklists {
+				if checklist.Title == "" {
+					return invalid("a checklist on card %q has no title", card.Title)
+				}
+				if utf8.RuneCountInString(checklist.Title) > maxChecklistTitleLength {
+					return invalid("a checklist title on card %q is longer than %d characters", card.Title, maxChecklistTitleLength)
+				}
+				for _, item := range checklist.Items {
+					if item.Text == "" {
+						return invalid("an item of checklist %q has no text", checklist.Title)
+					}
+					if utf8.RuneCountInString(item.Text) > maxChecklistItemLength {
+						return invalid("an item of checklist %q is longer than %d characters", checklist.Title, maxChecklistItemLength)
+					}
+				}
+			}
+			for _, comment := range card.Comments {
+				if comment.Body == "" {
+					return invalid("a comment on card %q is empty", card.Title)
+				}
+				if utf8.RuneCountInString(comment.Body) > maxCommentLength {
+					return invalid("a comment on card %q is longer than %d characters", card.Title, maxCommentLength)
+				}
+			}
+		}
+	}
+
+	return nil
+}
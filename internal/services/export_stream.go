@@ -0,0 +1,149 @@
+package services
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/repository"
+)
+
+// csvExportHeader names the columns of a CSV board export
+var csvExportHeader = []string{
+	"card_id", "title", "description", "column", "column_position", "position",
+	"priority", "swimlane", "start_at", "due_at", "created_at", "updated_at",
+}
+
+// markdownEscaper escapes characters that would otherwise format card and
+// column titles in Markdown exports
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"<", `\<`, ">", `\>`, "#", `\#`, "|", `\|`, "\r\n", " ", "\n", " ", "\r", " ",
+)
+
+// StreamableBoard checks that the user can view a board and loads it with its
+// columns for the streamed exports. Cards are read while writing.
+func (s *ExportService) StreamableBoard(boardID, userID uint) (*models.Board, error) {
+	if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	board, err := s.boardRepo.FindByIDWithColumns(boardID)
+	if err != nil {
+		return nil, ErrBoardNotFound
+	}
+	return board, nil
+}
+
+// WriteCSV writes a board as CSV with one row per card, in column and card
+// order
+func (s *ExportService) WriteCSV(w io.Writer, board *models.Board) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvExportHeader); err != nil {
+		return err
+	}
+
+	err := s.boardRepo.EachCardRow(board.ID, func(row *repository.CardRow) error {
+		return writer.Write([]string{
+			strconv.FormatUint(uint64(row.ID), 10),
+			csvCell(row.Title),
+			csvCell(row.Description),
+			csvCell(row.ColumnTitle),
+			strconv.Itoa(row.ColumnPosition),
+			strconv.Itoa(row.Position),
+			row.Priority,
+			csvCell(row.SwimlaneTitle),
+			exportTime(row.StartAt),
+			exportTime(row.DueAt),
+			exportTime(&row.CreatedAt),
+			exportTime(&row.UpdatedAt),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteMarkdown writes a board as Markdown with a heading per column and a
+// task list item per card
+func (s *ExportService) WriteMarkdown(w io.Writer, board *models.Board) error {
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, "# %s\n", markdownEscaper.Replace(board.Name))
+	if board.Description != "" {
+		fmt.Fprintf(out, "\n%s\n", board.Description)
+	}
+
+	columnIndex := make(map[uint]int, len(board.Columns))
+	for i, column := range board.Columns {
+		columnIndex[column.ID] = i
+	}
+
+	// Headings are written as cards reach their column, so empty columns
+	// between them still get one
+	next, cards := 0, 0
+	headingsThrough := func(last int) {
+		for ; next <= last; next++ {
+			if next > 0 && cards == 0 {
+				fmt.Fprint(out, "_No cards_\n")
+			}
+			fmt.Fprintf(out, "\n## %s\n\n", markdownEscaper.Replace(board.Columns[next].Title))
+			cards = 0
+		}
+	}
+
+	err := s.boardRepo.EachCardRow(board.ID, func(row *repository.CardRow) error {
+		headingsThrough(columnIndex[row.ColumnID])
+		cards++
+		fmt.Fprintf(out, "- [ ] %s%s\n", markdownEscaper.Replace(row.Title), markdownCardDetails(row))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	headingsThrough(len(board.Columns) - 1)
+	if next > 0 && cards == 0 {
+		fmt.Fprint(out, "_No cards_\n")
+	}
+
+	return out.Flush()
+}
+
+// markdownCardDetails describes a card's priority, swimlane and dates after its title
+func markdownCardDetails(row *repository.CardRow) string {
+	details := []string{row.Priority + " priority"}
+	if row.SwimlaneTitle != "" {
+		details = append(details, "swimlane: "+markdownEscaper.Replace(row.SwimlaneTitle))
+	}
+	if row.StartAt != nil {
+		details = append(details, "start: "+row.StartAt.UTC().Format(time.DateOnly))
+	}
+	if row.DueAt != nil {
+		details = append(details, "due: "+row.DueAt.UTC().Format(time.DateOnly))
+	}
+	return " (" + strings.Join(details, ", ") + ")"
+}
+
+// csvCell neutralizes text that spreadsheets would evaluate as a formula
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// exportTime formats an optional timestamp as RFC 3339 in UTC
+func exportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/icl00ud/goban/internal/events"
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/repository"
+)
+
+var (
+	ErrLabelNotFound     = errors.New("label not found")
+	ErrInvalidColor      = errors.New("color must be a hex value like #3b82f6")
+	ErrLabelWrongBoard   = errors.New("label belongs to a different board")
+	ErrLabelNameRequired = errors.New("label name is required")
+	ErrLabelNameTooLong  = errors.New("label name must be at most 50 characters")
+)
+
+// maxLabelNameLength matches the size of the label name column
+const maxLabelNameLength = 50
+
+type LabelService struct {
+	labelRepo  *repository.LabelRepository
+	cardRepo   *repository.CardRepository
+	columnRepo *repository.ColumnRepository
+	boardRepo  *repository.BoardRepository
+	broker     *events.Broker
+}
+
+func NewLabelService(labelRepo *repository.LabelRepository, cardRepo *repository.CardRepository, columnRepo *repository.ColumnRepository, boardRepo *repository.BoardRepository, broker *events.Broker) *LabelService {
+	return &LabelService{
+		labelRepo:  labelRepo,
+		cardRepo:   cardRepo,
+		columnRepo: columnRepo,
+		boardRepo:  boardRepo,
+		broker:     broker,
+	}
+}
+
+// List retrieves all labels of a board
+func (s *LabelService) List(boardID, userID uint) ([]models.Label, error) {
+	if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	return s.labelRepo.FindAllByBoardID(boardID)
+}
+
+// Create creates a new label on a board
+func (s *LabelService) Create(boardID, userID uint, name, color string) (*models.Label, error) {
+	if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrLabelNameRequired
+	}
+	if utf8.RuneCountInString(name) > maxLabelNameLength {
+		return nil, ErrLabelNameTooLong
+	}
+	if !models.ValidateColor(color) {
+		return nil, ErrInvalidColor
+	}
+
+	label := &models.Label{
+		Name:    name,
+		Color:   color,
+		BoardID: boardID,
+	}
+
+	if err := s.labelRepo.Create(label); err != nil {
+		return nil, err
+	}
+
+	publishEvent(s.broker, events.LabelCreated, boardID, userID, label)
+	return label, nil
+}
+
+// Update updates a board label
+func (s *LabelService) Update(boardID, labelID, userID uint, name, color string) (*models.Label, error) {
+	if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	label, err := s.findBoardLabel(boardID, labelID)
+	if err != nil {
+		return nil, err
+	}
+
+	if name = strings.TrimSpace(name); name != "" {
+		if utf8.RuneCountInString(name) > maxLabelNameLength {
+			return nil, ErrLabelNameTooLong
+		}
+		label.Name = name
+	}
+	if color != "" {
+		if !models.ValidateColor(color) {
+			return nil, ErrInvalidColor
+		}
+		label.Color = color
+	}
+
+	if err := s.labelRepo.Update(label); err != nil {
+		return nil, err
+	}
+
+	publishEvent(s.broker, events.LabelUpdated, boardID, userID, label)
+	return label, nil
+}
+
+// Delete deletes a board label and removes it from all cards
+func (s *LabelService) Delete(boardID, labelID, userID uint) error {
+	if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleEditor); err != nil {
+		return err
+	}
+
+	if _, err := s.findBoardLabel(boardID, labelID); err != nil {
+		return err
+	}
+
+	if err := s.labelRepo.Delete(labelID); err != nil {
+		return err
+	}
+
+	publishEvent(s.broker, events.LabelDeleted, boardID, userID, map[string]uint{"id": labelID})
+	return nil
+}
+
+// Attach adds a label to a card of the same board
+func (s *LabelService) Attach(cardID, labelID, userID uint) (*models.Card, error) {
+	return s.changeCardLabel(cardID, labelID, userID, s.labelRepo.Attach)
+}
+
+// Detach removes a label from a card
+func (s *LabelService) Detach(cardID, labelID, userID uint) (*models.Card, error) {
+	return s.changeCardLabel(cardID, labelID, userID, s.labelRepo.Detach)
+}
+
+// changeCardLabel applies a label change to a card after access checks
+func (s *LabelService) changeCardLabel(cardID, labelID, userID uint, apply func(*models.Card, *models.Label) error) (*models.Card, error) {
+	card, err := s.cardRepo.FindByID(cardID)
+	if err != nil {
+		return nil, ErrCardNotFound
+	}
+
+	// Get column to check board access
+	column, err := s.columnRepo.FindByID(card.ColumnID)
+	if err != nil {
+		return nil, ErrColumnNotFound
+	}
+
+	if err := checkBoardRole(s.boardRepo, column.BoardID, userID, models.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	label, err := s.labelRepo.FindByID(labelID)
+	if err != nil {
+		return nil, ErrLabelNotFound
+	}
+	if label.BoardID != column.BoardID {
+		return nil, ErrLabelWrongBoard
+	}
+
+	if err := apply(card, label); err != nil {
+		return nil, err
+	}
+
+	card, err = s.cardRepo.FindByIDWithDetails(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	publishEvent(s.broker, events.CardUpdated, column.BoardID, userID, card)
+	return card, nil
+}
+
+// findBoardLabel loads a label and ensures it belongs to the board
+func (s *LabelService) findBoardLabel(boardID, labelID uint) (*models.Label, error) {
+	label, err := s.labelRepo.FindByID(labelID)
+	if err != nil || label.BoardID != boardID {
+		return nil, ErrLabelNotFound
+	}
+	return label, nil
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxTrackedUnknownLogins bounds how many unknown email addresses failed
+// logins are tracked for
+const maxTrackedUnknownLogins = 10000
+
+// unknownLoginFailures counts failed logins for email addresses without an
+// account and locks them like accounts are locked, so a lockout does not
+// reveal which addresses are registered. Counts are kept in memory.
+type unknownLoginFailures struct {
+	mu      sync.Mutex
+	entries map[string]*unknownLogin
+}
+
+type unknownLogin struct {
+	failures    int
+	lockedUntil time.Time
+	lastFailure time.Time
+}
+
+func newUnknownLoginFailures() *unknownLoginFailures {
+	return &unknownLoginFailures{entries: make(map[string]*unknownLogin)}
+}
+
+// check returns an AccountLockedError while the address is locked out
+func (f *unknownLoginFailures) check(email string, now time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if entry, ok := f.entries[strings.ToLower(email)]; ok && now.Before(entry.lockedUntil) {
+		return &AccountLockedError{Until: entry.lockedUntil}
+	}
+	return nil
+}
+
+// record counts a failed login for the address, locking it until lockedUntil
+// once maxFailures is reached the way UserRepository.RecordLoginFailure does
+func (f *unknownLoginFailures) record(email string, maxFailures int, lockedUntil, now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := strings.ToLower(email)
+	entry, ok := f.entries[key]
+	if !ok {
+		if len(f.entries) >= maxTrackedUnknownLogins {
+			f.prune(now)
+			if len(f.entries) >= maxTrackedUnknownLogins {
+				return
+			}
+		}
+		entry = &unknownLogin{}
+		f.entries[key] = entry
+	}
+
+	entry.lastFailure = now
+	entry.failures++
+	if entry.failures >= maxFailures {
+		entry.failures = 0
+		entry.lockedUntil = lockedUntil
+	}
+}
+
+// prune forgets addresses that are not locked and have not failed for an hour
+func (f *unknownLoginFailures) prune(now time.Time) {
+	for key, entry := range f.entries {
+		if !now.Before(entry.lockedUntil) && now.Sub(entry.lastFailure) > time.Hour {
+			delete(f.entries, key)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/oidc"
+	"github.com/icl00ud/goban/internal/repository"
+)
+
+var (
+	ErrOIDCDisabled          = errors.New("single sign-on is not configured")
+	ErrOIDCFailed            = errors.New("single sign-on failed")
+	ErrOIDCEmailNotVerified  = errors.New("your identity provider has not verified your email address")
+	ErrOIDCDomainNotAllowed  = errors.New("your email domain is not allowed to sign in")
+	ErrOIDCInvalidLoginState = errors.New("single sign-on attempt expired or was tampered with; try again")
+	ErrOIDCAccountUnverified = errors.New("an account with this email already exists; sign in with your password and verify your email address to use single sign-on")
+)
+
+// OIDCLoginState holds the secrets of one sign-in attempt, kept by the
+// browser between the login redirect and the callback
+type OIDCLoginState struct {
+	State    string
+	Nonce    string
+	Verifier string
+}
+
+type OIDCService struct {
+	provider       *oidc.Provider
+	identityRepo   *repository.OIDCIdentityRepository
+	userRepo       *repository.UserRepository
+	allowedDomains []string
+	registration   RegistrationPolicy
+}
+
+// NewOIDCService creates the single sign-on service. A nil provider disables
+// single sign-on; an empty domain list allows every domain. New accounts are
+// only created when the registration policy allows it.
+func NewOIDCService(provider *oidc.Provider, identityRepo *repository.OIDCIdentityRepository, userRepo *repository.UserRepository, allowedDomains []string, registration RegistrationPolicy) *OIDCService {
+	return &OIDCService{
+		provider:       provider,
+		identityRepo:   identityRepo,
+		userRepo:       userRepo,
+		allowedDomains: allowedDomains,
+		registration:   registration,
+	}
+}
+
+// LoginURL starts a sign-in attempt, returning the provider URL to send the
+// user to and the state to keep until the callback
+func (s *OIDCService) LoginURL(ctx context.Context, redirectURL string) (string, *OIDCLoginState, error) {
+	if s.provider == nil {
+		return "", nil, ErrOIDCDisabled
+	}
+
+	state := &OIDCLoginState{}
+	for _, secret := range []*string{&state.State, &state.Nonce, &state.Verifier} {
+		value, err := randomToken()
+		if err != nil {
+			return "", nil, err
+		}
+		*secret = value
+	}
+
+	authURL, err := s.provider.AuthCodeURL(ctx, redirectURL, state.State, state.Nonce, state.Verifier)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrOIDCFailed, err)
+	}
+	return authURL, state, nil
+}
+
+// Callback completes a sign-in attempt and returns the signed-in user. Users
+// are matched by provider identity, then linked by email, and otherwise
+// created if the registration policy allows it. Only accounts that verified
+// their email are linked, so whoever registered an address first cannot take
+// over the provider's account.
+func (s *OIDCService) Callback(ctx context.Context, redirectURL, code, returnedState string, state *OIDCLoginState) (*models.User, error) {
+	if s.provider == nil {
+		return nil, ErrOIDCDisabled
+	}
+	if state == nil || code == "" || returnedState == "" || returnedState != state.State {
+		return nil, ErrOIDCInvalidLoginState
+	}
+
+	claims, err := s.provider.Exchange(ctx, redirectURL, code, state.Verifier, state.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCFailed, err)
+	}
+
+	if !claims.EmailVerified || !strings.Contains(claims.Email, "@") {
+		return nil, ErrOIDCEmailNotVerified
+	}
+	if !emailDomainAllowed(claims.Email, s.allowedDomains) {
+		return nil, ErrOIDCDomainNotAllowed
+	}
+
+	issuer := s.provider.Issuer()
+	if identity, err := s.identityRepo.FindByIssuerSubject(issuer, claims.Subject); err == nil && identity.User.ID != 0 {
+		if identity.User.DisabledAt != nil {
+			return nil, ErrAccountDisabled
+		}
+		return &identity.User, nil
+	}
+
+	identity := &models.OIDCIdentity{Issuer: issuer, Subject: claims.Subject}
+
+	if user, err := s.userRepo.FindByEmailFold(claims.Email); err == nil {
+		if user.DisabledAt != nil {
+			return nil, ErrAccountDisabled
+		}
+		if user.EmailVerifiedAt == nil {
+			return nil, ErrOIDCAccountUnverified
+		}
+		identity.UserID = user.ID
+		if err := s.identityRepo.Link(identity); err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+
+	if err := s.registration.checkSignUp(claims.Email); err != nil {
+		return nil, err
+	}
+
+	// Provisioned users have no password and can only sign in through SSO
+	verifiedAt := time.Now().UTC()
+	user := &models.User{
+		Email:           claims.Email,
+		Name:            provisionedName(claims),
+		EmailVerifiedAt: &verifiedAt,
+	}
+	if err := s.identityRepo.Provision(user, identity); err != nil {
+		return nil, err
+	}
+
+	promoted, err := s.userRepo.PromoteIfFirst(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	user.IsAdmin = promoted
+
+	return user, nil
+}
+
+// emailDomainAllowed checks the email's domain against the allowed domains.
+// An empty list allows every domain.
+func emailDomainAllowed(email string, allowedDomains []string) bool {
+	if len(allowedDomains) == 0 {
+		return true
+	}
+
+	domain := email[strings.LastIndex(email, "@")+1:]
+	for _, allowed := range allowedDomains {
+		if strings.EqualFold(domain, strings.TrimPrefix(allowed, "@")) {
+			return true
+		}
+	}
+	return false
+}
+
+// provisionedName names a new user after their provider profile, falling
+// back to the local part of their email
+func provisionedName(claims *oidc.Claims) string {
+	name := strings.TrimSpace(claims.Name)
+	if name == "" {
+		name = claims.Email[:strings.LastIndex(claims.Email, "@")]
+	}
+	return truncateRunes(name, 100)
+}
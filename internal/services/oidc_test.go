@@ -0,0 +1,36 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/icl00ud/goban/internal/oidc"
+)
+
+func TestOIDCCallbackRejectsInvalidLoginState(t *testing.T) {
+	// The state is checked before the provider is contacted
+	service := NewOIDCService(oidc.NewProvider(oidc.Config{Issuer: "http://127.0.0.1:0"}), nil, nil, nil, RegistrationPolicy{Mode: RegistrationOpen})
+	state := &OIDCLoginState{State: "state", Nonce: "nonce", Verifier: "verifier"}
+
+	tests := []struct {
+		name          string
+		code          string
+		returnedState string
+		state         *OIDCLoginState
+	}{
+		{"missing cookie", "code", "state", nil},
+		{"missing code", "", "state", state},
+		{"missing state", "code", "", state},
+		{"mismatched state", "code", "forged", state},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.Callback(context.Background(), "http://goban.test/callback", tt.code, tt.returnedState, tt.state)
+			if !errors.Is(err, ErrOIDCInvalidLoginState) {
+				t.Fatalf("expected ErrOIDCInvalidLoginState, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/icl00ud/goban/internal/models"
+)
+
+var (
+	ErrRegistrationClosed    = errors.New("registration is closed")
+	ErrInviteRequired        = errors.New("an invite code is required to register")
+	ErrInvalidInvite         = errors.New("invite code is invalid, expired or already used")
+	ErrEmailDomainNotAllowed = errors.New("registration is not open to your email domain")
+)
+
+// Registration modes
+const (
+	RegistrationOpen   = "open"
+	RegistrationInvite = "invite"
+	RegistrationClosed = "closed"
+)
+
+// RegistrationPolicy decides who may create an account. Open registration can
+// be limited to some email domains; a valid invite admits anyone unless
+// registration is closed.
+type RegistrationPolicy struct {
+	Mode           string
+	AllowedDomains []string
+}
+
+// ValidRegistrationMode checks if the mode is a known registration mode
+func ValidRegistrationMode(mode string) bool {
+	return mode == RegistrationOpen || mode == RegistrationInvite || mode == RegistrationClosed
+}
+
+// checkSignUp applies the policy to an account created without an invite,
+// such as by single sign-on
+func (p RegistrationPolicy) checkSignUp(email string) error {
+	if p.Mode != RegistrationOpen {
+		return ErrRegistrationClosed
+	}
+	if !emailDomainAllowed(email, p.AllowedDomains) {
+		return ErrEmailDomainNotAllowed
+	}
+	return nil
+}
+
+// RegistrationPolicy returns the instance's registration policy
+func (s *AuthService) RegistrationPolicy() RegistrationPolicy {
+	return s.registration
+}
+
+// checkRegistration applies the registration policy to a new account for
+// email, returning the invite it registers with, if any
+func (s *AuthService) checkRegistration(email, inviteCode string) (*models.Invite, error) {
+	inviteCode = strings.TrimSpace(inviteCode)
+
+	switch {
+	case s.registration.Mode == RegistrationClosed:
+		return nil, ErrRegistrationClosed
+	case inviteCode != "":
+		invite, err := s.inviteRepo.FindByHash(hashToken(inviteCode))
+		if err != nil || !invite.Usable(time.Now()) {
+			return nil, ErrInvalidInvite
+		}
+		if invite.Email != "" && !strings.EqualFold(invite.Email, email) {
+			return nil, ErrInvalidInvite
+		}
+		return invite, nil
+	case s.registration.Mode == RegistrationInvite:
+		return nil, ErrInviteRequired
+	case !emailDomainAllowed(email, s.registration.AllowedDomains):
+		return nil, ErrEmailDomainNotAllowed
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/icl00ud/goban/internal/repository"
+)
+
+// Search limits
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 50
+	maxSearchTerms     = 10
+)
+
+// searchTermPattern splits queries into words, dropping punctuation and
+// operators of the underlying full-text syntax
+var searchTermPattern = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+type SearchService struct {
+	searchRepo *repository.SearchRepository
+}
+
+func NewSearchService(searchRepo *repository.SearchRepository) *SearchService {
+	return &SearchService{searchRepo: searchRepo}
+}
+
+// Search finds boards, cards and comments matching every word of the query
+// on all boards the user can access, best matches first
+func (s *SearchService) Search(userID uint, query string, limit int) ([]repository.SearchHit, error) {
+	terms := searchTermPattern.FindAllString(strings.ToLower(query), maxSearchTerms)
+	if len(terms) == 0 {
+		return []repository.SearchHit{}, nil
+	}
+
+	if limit < 1 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	return s.searchRepo.Search(userID, terms, limit)
+}
@@ -0,0 +1,303 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/repository"
+	"github.com/icl00ud/goban/internal/utils"
+)
+
+var (
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrInvalidSession      = errors.New("invalid or expired session")
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+)
+
+// SessionLifetime is how long a session stays signed in without being
+// refreshed; every refresh extends it
+const SessionLifetime = 7 * 24 * time.Hour
+
+// refreshReuseGrace tolerates a replaced refresh token for a moment, so two
+// tabs refreshing at once don't end the session. Later reuse of a replaced
+// token means it leaked, and the session is revoked.
+const refreshReuseGrace = 10 * time.Second
+
+// Session bookkeeping intervals
+const (
+	sessionSeenResolution = time.Minute
+	sessionPruneInterval  = time.Hour
+	sessionRetention      = 24 * time.Hour
+)
+
+// Maximum stored lengths of the client details of a session
+const (
+	maxUserAgentLength = 255
+	maxIPAddressLength = 45
+)
+
+// SessionTokens are the credentials issued when a session starts or refreshes
+type SessionTokens struct {
+	Session      *models.Session
+	AccessToken  string
+	RefreshToken string
+}
+
+type SessionService struct {
+	sessionRepo *repository.SessionRepository
+	jwtSecret   string
+}
+
+func NewSessionService(sessionRepo *repository.SessionRepository, jwtSecret string) *SessionService {
+	return &SessionService{
+		sessionRepo: sessionRepo,
+		jwtSecret:   jwtSecret,
+	}
+}
+
+// Start creates a session for a user who just signed in on a device
+func (s *SessionService) Start(user *models.User, userAgent, ipAddress string) (*SessionTokens, error) {
+	jti, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	userAgent = truncateRunes(userAgent, maxUserAgentLength)
+	now := time.Now().UTC()
+	session := &models.Session{
+		JTI:              jti,
+		RefreshTokenHash: hashToken(refreshToken),
+		UserID:           user.ID,
+		Device:           describeDevice(userAgent),
+		IPAddress:        truncateRunes(ipAddress, maxIPAddressLength),
+		UserAgent:        userAgent,
+		RefreshedAt:      now,
+		LastSeenAt:       now,
+		ExpiresAt:        now.Add(SessionLifetime),
+	}
+	if err := s.sessionRepo.Create(session); err != nil {
+		return nil, err
+	}
+
+	return s.issue(session, user, refreshToken)
+}
+
+// Refresh rotates a session's refresh token and issues a new access token
+func (s *SessionService) Refresh(refreshToken, userAgent, ipAddress string) (*SessionTokens, *models.User, error) {
+	hash := hashToken(refreshToken)
+	now := time.Now().UTC()
+
+	session, err := s.sessionRepo.FindByRefreshHash(hash)
+	if err != nil {
+		s.detectReuse(hash, now)
+		return nil, nil, ErrInvalidRefreshToken
+	}
+	if !session.Active(now) || session.User.ID == 0 || session.User.DisabledAt != nil {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+
+	newRefreshToken, err := randomToken()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	session.PreviousRefreshHash = session.RefreshTokenHash
+	session.RefreshTokenHash = hashToken(newRefreshToken)
+	session.IPAddress = truncateRunes(ipAddress, maxIPAddressLength)
+	if userAgent != "" {
+		session.UserAgent = truncateRunes(userAgent, maxUserAgentLength)
+		session.Device = describeDevice(session.UserAgent)
+	}
+	session.RefreshedAt = now
+	session.LastSeenAt = now
+	session.ExpiresAt = now.Add(SessionLifetime)
+	if err := s.sessionRepo.Update(session); err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := s.issue(session, &session.User, newRefreshToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tokens, &session.User, nil
+}
+
+// detectReuse revokes the session a replaced refresh token belonged to, unless
+// it was replaced only moments ago
+func (s *SessionService) detectReuse(hash string, now time.Time) {
+	session, err := s.sessionRepo.FindByPreviousRefreshHash(hash)
+	if err != nil || now.Sub(session.RefreshedAt) < refreshReuseGrace {
+		return
+	}
+
+	log.Printf("Replaced refresh token reused for session %d of user %d; revoking it", session.ID, session.UserID)
+	if err := s.sessionRepo.Revoke(session.ID, now); err != nil {
+		log.Printf("Failed to revoke session %d: %v", session.ID, err)
+	}
+}
+
+// issue signs an access token for a session
+func (s *SessionService) issue(session *models.Session, user *models.User, refreshToken string) (*SessionTokens, error) {
+	accessToken, err := utils.GenerateToken(user.ID, user.Email, session.JTI, s.jwtSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SessionTokens{
+		Session:      session,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// Authenticate validates an access token and the session it belongs to
+func (s *SessionService) Authenticate(accessToken string) (*models.Session, *utils.JWTClaims, error) {
+	claims, err := utils.ValidateToken(accessToken, s.jwtSecret)
+	if err != nil || claims.ID == "" {
+		return nil, nil, ErrInvalidSession
+	}
+
+	session, err := s.sessionRepo.FindByJTI(claims.ID)
+	if err != nil || session.UserID != claims.UserID {
+		return nil, nil, ErrInvalidSession
+	}
+
+	now := time.Now().UTC()
+	if !session.Active(now) {
+		return nil, nil, ErrInvalidSession
+	}
+
+	if now.Sub(session.LastSeenAt) >= sessionSeenResolution {
+		if err := s.sessionRepo.TouchLastSeen(session.ID, now); err != nil {
+			log.Printf("Failed to record use of session %d: %v", session.ID, err)
+		}
+		session.LastSeenAt = now
+	}
+
+	return session, claims, nil
+}
+
+// StillActive checks that a session authenticated earlier has not since been
+// revoked or expired, for long-lived requests such as event streams
+func (s *SessionService) StillActive(sessionID uint) bool {
+	session, err := s.sessionRepo.FindByID(sessionID)
+	return err == nil && session.Active(time.Now().UTC())
+}
+
+// List retrieves the user's active sessions, most recently seen first
+func (s *SessionService) List(userID uint) ([]models.Session, error) {
+	return s.sessionRepo.FindActiveByUserID(userID, time.Now().UTC())
+}
+
+// Revoke ends one of the user's sessions
+func (s *SessionService) Revoke(sessionID, userID uint) error {
+	session, err := s.sessionRepo.FindByID(sessionID)
+	if err != nil || session.UserID != userID || !session.Active(time.Now().UTC()) {
+		return ErrSessionNotFound
+	}
+	return s.sessionRepo.Revoke(sessionID, time.Now().UTC())
+}
+
+// RevokeAll ends every session of the user
+func (s *SessionService) RevokeAll(userID uint) error {
+	return s.sessionRepo.RevokeAllByUserID(userID, time.Now().UTC())
+}
+
+// End revokes the session identified by a refresh token or, failing that, an
+// access token. Unknown or already ended sessions are ignored.
+func (s *SessionService) End(refreshToken, accessToken string) error {
+	now := time.Now().UTC()
+
+	if refreshToken != "" {
+		if session, err := s.sessionRepo.FindByRefreshHash(hashToken(refreshToken)); err == nil {
+			return s.sessionRepo.Revoke(session.ID, now)
+		}
+	}
+
+	if accessToken != "" {
+		claims, err := utils.ValidateToken(accessToken, s.jwtSecret)
+		if err != nil || claims.ID == "" {
+			return nil
+		}
+		if session, err := s.sessionRepo.FindByJTI(claims.ID); err == nil {
+			return s.sessionRepo.Revoke(session.ID, now)
+		}
+	}
+
+	return nil
+}
+
+// StartPruner deletes long-ended sessions now and then periodically in the
+// background
+func (s *SessionService) StartPruner() {
+	go func() {
+		ticker := time.NewTicker(sessionPruneInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.sessionRepo.DeleteEndedBefore(time.Now().UTC().Add(-sessionRetention)); err != nil {
+				log.Printf("Failed to prune sessions: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// randomToken generates an unguessable hex token
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// describeDevice names the browser and operating system of a user agent,
+// falling back to the start of the user agent itself
+func describeDevice(userAgent string) string {
+	if userAgent == "" {
+		return "Unknown device"
+	}
+
+	browser := ""
+	for _, candidate := range []struct{ token, name string }{
+		{"Edg/", "Edge"}, {"OPR/", "Opera"}, {"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"}, {"Safari/", "Safari"}, {"curl/", "curl"},
+	} {
+		if strings.Contains(userAgent, candidate.token) {
+			browser = candidate.name
+			break
+		}
+	}
+
+	system := ""
+	for _, candidate := range []struct{ token, name string }{
+		{"Android", "Android"}, {"iPhone", "iOS"}, {"iPad", "iPadOS"},
+		{"Windows", "Windows"}, {"Mac OS X", "macOS"}, {"CrOS", "ChromeOS"}, {"Linux", "Linux"},
+	} {
+		if strings.Contains(userAgent, candidate.token) {
+			system = candidate.name
+			break
+		}
+	}
+
+	switch {
+	case browser != "" && system != "":
+		return browser + " on " + system
+	case browser != "":
+		return browser
+	case system != "":
+		return system
+	default:
+		return truncateRunes(userAgent, 100)
+	}
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/icl00ud/goban/internal/events"
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/repository"
+)
+
+var (
+	ErrSwimlaneNotFound   = errors.New("swimlane not found")
+	ErrSwimlaneWrongBoard = errors.New("swimlane belongs to a different board")
+)
+
+type SwimlaneService struct {
+	swimlaneRepo *repository.SwimlaneRepository
+	boardRepo    *repository.BoardRepository
+	activityRepo *repository.ActivityRepository
+	broker       *events.Broker
+}
+
+func NewSwimlaneService(swimlaneRepo *repository.SwimlaneRepository, boardRepo *repository.BoardRepository, activityRepo *repository.ActivityRepository, broker *events.Broker) *SwimlaneService {
+	return &SwimlaneService{
+		swimlaneRepo: swimlaneRepo,
+		boardRepo:    boardRepo,
+		activityRepo: activityRepo,
+		broker:       broker,
+	}
+}
+
+// List retrieves all swimlanes of a board in order
+func (s *SwimlaneService) List(boardID, userID uint) ([]models.Swimlane, error) {
+	if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	return s.swimlaneRepo.FindAllByBoardID(boardID)
+}
+
+// Create creates a new swimlane at the bottom of the board
+func (s *SwimlaneService) Create(boardID, userID uint, title string) (*models.Swimlane, error) {
+	if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	swimlane := &models.Swimlane{
+		Title:    title,
+		Position: s.swimlaneRepo.GetMaxPosition(boardID) + 1,
+		BoardID:  boardID,
+	}
+
+	if err := s.swimlaneRepo.Create(swimlane); err != nil {
+		return nil, err
+	}
+
+	recordActivity(s.activityRepo, boardID, userID, models.EntitySwimlane, swimlane.ID, models.ActionCreated, nil, swimlane)
+	publishEvent(s.broker, events.SwimlaneCreated, boardID, userID, swimlane)
+	return swimlane, nil
+}
+
+// Update renames, collapses or expands a swimlane. A nil collapsed leaves
+// the state unchanged.
+func (s *SwimlaneService) Update(boardID, swimlaneID, userID uint, title string, collapsed *bool) (*models.Swimlane, error) {
+	if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	swimlane, err := s.findBoardSwimlane(boardID, swimlaneID)
+	if err != nil {
+		return nil, err
+	}
+	before := *swimlane
+
+	if title != "" {
+		swimlane.Title = title
+	}
+	if collapsed != nil {
+		swimlane.Collapsed = *collapsed
+	}
+
+	if err := s.swimlaneRepo.Update(swimlane); err != nil {
+		return nil, err
+	}
+
+	recordActivity(s.activityRepo, boardID, userID, models.EntitySwimlane, swimlane.ID, models.ActionUpdated, before, swimlane)
+	publishEvent(s.broker, events.SwimlaneUpdated, boardID, userID, swimlane)
+	return swimlane, nil
+}
+
+// Delete deletes a swimlane. Its cards stay in their columns without a swimlane.
+func (s *SwimlaneService) Delete(boardID, swimlaneID, userID uint) error {
+	if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleEditor); err != nil {
+		return err
+	}
+
+	swimlane, err := s.findBoardSwimlane(boardID, swimlaneID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.swimlaneRepo.Delete(swimlaneID); err != nil {
+		return err
+	}
+
+	recordActivity(s.activityRepo, boardID, userID, models.EntitySwimlane, swimlaneID, models.ActionDeleted, swimlane, nil)
+	publishEvent(s.broker, events.SwimlaneDeleted, boardID, userID, map[string]uint{"id": swimlaneID})
+	return nil
+}
+
+// Reorder reorders a board's swimlanes based on the provided order
+func (s *SwimlaneService) Reorder(boardID, userID uint, swimlaneIDs []uint) error {
+	if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleEditor); err != nil {
+		return err
+	}
+
+	positions := make([]int, len(swimlaneIDs))
+	for i := range swimlaneIDs {
+		positions[i] = i
+	}
+
+	if err := s.swimlaneRepo.UpdatePositions(boardID, swimlaneIDs, positions); err != nil {
+		return err
+	}
+
+	recordActivity(s.activityRepo, boardID, userID, models.EntityBoard, boardID, models.ActionReordered, nil, map[string][]uint{"swimlane_ids": swimlaneIDs})
+	publishEvent(s.broker, events.SwimlanesReordered, boardID, userID, map[string][]uint{"swimlane_ids": swimlaneIDs})
+	return nil
+}
+
+// findBoardSwimlane finds a swimlane and ensures it belongs to the board
+func (s *SwimlaneService) findBoardSwimlane(boardID, swimlaneID uint) (*models.Swimlane, error) {
+	swimlane, err := s.swimlaneRepo.FindByID(swimlaneID)
+	if err != nil || swimlane.BoardID != boardID {
+		return nil, ErrSwimlaneNotFound
+	}
+	return swimlane, nil
+}
+
+// checkCardSwimlane verifies that a swimlane a card is placed in belongs to
+// the card's board. A nil swimlane is always valid.
+func checkCardSwimlane(swimlaneRepo *repository.SwimlaneRepository, boardID uint, swimlaneID *uint) error {
+	if swimlaneID == nil {
+		return nil
+	}
+
+	swimlane, err := swimlaneRepo.FindByID(*swimlaneID)
+	if err != nil {
+		return ErrSwimlaneNotFound
+	}
+	if swimlane.BoardID != boardID {
+		return ErrSwimlaneWrongBoard
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/repository"
+)
+
+var (
+	ErrTemplateNotFound     = errors.New("template not found")
+	ErrTemplateBuiltIn      = errors.New("built-in templates can't be deleted")
+	ErrTemplateNameRequired = errors.New("template name is required")
+)
+
+type TemplateService struct {
+	templateRepo *repository.TemplateRepository
+	boardRepo    *repository.BoardRepository
+	labelRepo    *repository.LabelRepository
+}
+
+func NewTemplateService(templateRepo *repository.TemplateRepository, boardRepo *repository.BoardRepository, labelRepo *repository.LabelRepository) *TemplateService {
+	return &TemplateService{
+		templateRepo: templateRepo,
+		boardRepo:    boardRepo,
+		labelRepo:    labelRepo,
+	}
+}
+
+// List retrieves the built-in templates and the user's own templates
+func (s *TemplateService) List(userID uint) ([]models.BoardTemplate, error) {
+	return s.templateRepo.FindAllForUser(userID)
+}
+
+// GetByID retrieves a built-in template or one of the user's templates
+func (s *TemplateService) GetByID(templateID, userID uint) (*models.BoardTemplate, error) {
+	return findUsableTemplate(s.templateRepo, templateID, userID)
+}
+
+// SaveFromBoard saves a board's columns, WIP limits, labels and swimlanes as
+// a new template of the user, optionally with its cards as sample cards
+func (s *TemplateService) SaveFromBoard(boardID, userID uint, name, description string, includeCards bool) (*models.BoardTemplate, error) {
+	if err := checkBoardRole(s.boardRepo, boardID, userID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		return nil, ErrTemplateNameRequired
+	}
+
+	board, err := s.boardRepo.FindByIDWithDetails(boardID)
+	if err != nil {
+		return nil, ErrBoardNotFound
+	}
+
+	labels, err := s.labelRepo.FindAllByBoardID(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	structure, err := json.Marshal(boardStructure(board, labels, includeCards))
+	if err != nil {
+		return nil, err
+	}
+
+	template := &models.BoardTemplate{
+		Name:        name,
+		Description: description,
+		UserID:      &userID,
+		Structure:   string(structure),
+	}
+
+	if err := s.templateRepo.Create(template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// Delete deletes one of the user's templates
+func (s *TemplateService) Delete(templateID, userID uint) error {
+	template, err := findUsableTemplate(s.templateRepo, templateID, userID)
+	if err != nil {
+		return err
+	}
+
+	if template.BuiltIn {
+		return ErrTemplateBuiltIn
+	}
+
+	return s.templateRepo.Delete(templateID)
+}
+
+// SyncBuiltIns creates the built-in templates or updates them to the version
+// shipped with the application
+func (s *TemplateService) SyncBuiltIns() error {
+	for _, builtIn := range builtInTemplates {
+		structure, err := json.Marshal(builtIn.structure)
+		if err != nil {
+			return err
+		}
+
+		template, err := s.templateRepo.FindBuiltInByName(builtIn.name)
+		if err != nil {
+			template = &models.BoardTemplate{Name: builtIn.name, BuiltIn: true}
+		}
+		template.Description = builtIn.description
+		template.Structure = string(structure)
+
+		if template.ID == 0 {
+			err = s.templateRepo.Create(template)
+		} else {
+			err = s.templateRepo.Update(template)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// findUsableTemplate finds a template the user may see and create boards
+// from: a built-in one or one of their own
+func findUsableTemplate(templateRepo *repository.TemplateRepository, templateID, userID uint) (*models.BoardTemplate, error) {
+	template, err := templateRepo.FindByID(templateID)
+	if err != nil {
+		return nil, ErrTemplateNotFound
+	}
+
+	if !template.BuiltIn && (template.UserID == nil || *template.UserID != userID) {
+		return nil, ErrTemplateNotFound
+	}
+
+	return template, nil
+}
+
+// boardStructure captures the layout of a board loaded with its details
+func boardStructure(board *models.Board, labels []models.Label, includeCards bool) *models.TemplateStructure {
+	structure := &models.TemplateStructure{
+		WIPLimitMode: board.WIPLimitMode,
+		Columns:      make([]models.TemplateColumn, len(board.Columns)),
+	}
+
+	for _, label := range labels {
+		structure.Labels = append(structure.Labels, models.TemplateLabel{Name: label.Name, Color: label.Color})
+	}
+
+	swimlanes := make(map[uint]string, len(board.Swimlanes))
+	for _, swimlane := range board.Swimlanes {
+		structure.Swimlanes = append(structure.Swimlanes, swimlane.Title)
+		swimlanes[swimlane.ID] = swimlane.Title
+	}
+
+	for i, column := range board.Columns {
+		structure.Columns[i] = models.TemplateColumn{Title: column.Title, WIPLimit: column.WIPLimit}
+		if !includeCards {
+			continue
+		}
+
+		for _, card := range column.Cards {
+			sample := models.TemplateCard{
+				Title:       card.Title,
+				Description: card.Description,
+				Priority:    card.Priority,
+			}
+			if card.SwimlaneID != nil {
+				sample.Swimlane = swimlanes[*card.SwimlaneID]
+			}
+			for _, label := range card.Labels {
+				sample.Labels = append(sample.Labels, label.Name)
+			}
+			structure.Columns[i].Cards = append(structure.Columns[i].Cards, sample)
+		}
+	}
+
+	return structure
+}
+
+// wipLimit returns a pointer to a WIP limit for template definitions
+func wipLimit(limit int) *int {
+	return &limit
+}
+
+// builtInTemplates ship with the application and are synced on startup
+var builtInTemplates = []struct {
+	name        string
+	description string
+	structure   models.TemplateStructure
+}{
+	{
+		name:        "Scrum",
+		description: "Sprint workflow from product backlog to done, with review and WIP limits on work in flight",
+		structure: models.TemplateStructure{
+			WIPLimitMode: models.WIPLimitWarn,
+			Columns: []models.TemplateColumn{
+				{Title: "Product Backlog"},
+				{Title: "Sprint Backlog"},
+				{Title: "In Progress", WIPLimit: wipLimit(5)},
+				{Title: "Review", WIPLimit: wipLimit(3)},
+				{Title: "Done"},
+			},
+			Labels: []models.TemplateLabel{
+				{Name: "Story", Color: "#3b82f6"},
+				{Name: "Bug", Color: "#ef4444"},
+				{Name: "Task", Color: "#10b981"},
+				{Name: "Spike", Color: "#8b5cf6"},
+			},
+		},
+	},
+	{
+		name:        "Bug triage",
+		description: "Track reported bugs from intake through triage, fixing and verification",
+		structure: models.TemplateStructure{
+			WIPLimitMode: models.WIPLimitWarn,
+			Columns: []models.TemplateColumn{
+				{Title: "New"},
+				{Title: "Triaged"},
+				{Title: "In Progress", WIPLimit: wipLimit(3)},
+				{Title: "Fixed"},
+				{Title: "Verified"},
+				{Title: "Won't Fix"},
+			},
+			Labels: []models.TemplateLabel{
+				{Name: "Critical", Color: "#dc2626"},
+				{Name: "Major", Color: "#f97316"},
+				{Name: "Minor", Color: "#eab308"},
+				{Name: "Regression", Color: "#8b5cf6"},
+				{Name: "Needs Info", Color: "#6b7280"},
+			},
+		},
+	},
+	{
+		name:        "Personal",
+		description: "A simple personal task list that keeps a handful of things in progress",
+		structure: models.TemplateStructure{
+			WIPLimitMode: models.WIPLimitWarn,
+			Columns: []models.TemplateColumn{
+				{Title: "To Do", Cards: []models.TemplateCard{
+					{Title: "Add your first task", Description: "Drag cards to **Doing** when you start on them.", Labels: []string{"Home"}},
+				}},
+				{Title: "Doing", WIPLimit: wipLimit(3)},
+				{Title: "Done"},
+			},
+			Labels: []models.TemplateLabel{
+				{Name: "Home", Color: "#10b981"},
+				{Name: "Work", Color: "#3b82f6"},
+				{Name: "Errand", Color: "#f59e0b"},
+			},
+		},
+	},
+}
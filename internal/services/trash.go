@@ -0,0 +1,229 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/icl00ud/goban/internal/events"
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/repository"
+	"github.com/icl00ud/goban/internal/storage"
+)
+
+var (
+	ErrTrashItemNotFound  = errors.New("item not found in trash")
+	ErrTrashParentDeleted = errors.New("the board or column containing this item is deleted; restore it first")
+)
+
+// trashPurgeInterval is how often expired trash is purged
+const trashPurgeInterval = time.Hour
+
+type TrashService struct {
+	trashRepo    *repository.TrashRepository
+	boardRepo    *repository.BoardRepository
+	columnRepo   *repository.ColumnRepository
+	cardRepo     *repository.CardRepository
+	activityRepo *repository.ActivityRepository
+	store        storage.Storage
+	broker       *events.Broker
+	retention    time.Duration
+}
+
+// NewTrashService creates the trash service. Deleted items are purged after
+// retentionDays; zero or less keeps them forever.
+func NewTrashService(trashRepo *repository.TrashRepository, boardRepo *repository.BoardRepository, columnRepo *repository.ColumnRepository, cardRepo *repository.CardRepository, activityRepo *repository.ActivityRepository, store storage.Storage, broker *events.Broker, retentionDays int) *TrashService {
+	return &TrashService{
+		trashRepo:    trashRepo,
+		boardRepo:    boardRepo,
+		columnRepo:   columnRepo,
+		cardRepo:     cardRepo,
+		activityRepo: activityRepo,
+		store:        store,
+		broker:       broker,
+		retention:    time.Duration(retentionDays) * 24 * time.Hour,
+	}
+}
+
+// List retrieves the deleted boards the user owns and the deleted columns and
+// cards of boards the user can edit. Columns and cards deleted together with
+// their board or column are restored with it and are not listed separately.
+func (s *TrashService) List(userID uint) ([]models.Board, []models.Column, []models.Card, error) {
+	boards, err := s.trashRepo.FindDeletedBoards(userID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	columns, err := s.trashRepo.FindDeletedColumns(userID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	cards, err := s.trashRepo.FindDeletedCards(userID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return boards, columns, cards, nil
+}
+
+// PurgeAt returns when an item deleted at the given time will be purged, or
+// nil when trash is kept forever
+func (s *TrashService) PurgeAt(deletedAt time.Time) *time.Time {
+	if s.retention <= 0 {
+		return nil
+	}
+	purgeAt := deletedAt.Add(s.retention)
+	return &purgeAt
+}
+
+// RestoreBoard restores a deleted board at the end of the owner's boards,
+// restricted to board owners
+func (s *TrashService) RestoreBoard(boardID, userID uint) (*models.Board, error) {
+	board, err := s.trashRepo.FindDeletedBoard(boardID)
+	if err != nil {
+		return nil, ErrTrashItemNotFound
+	}
+
+	if err := s.checkRole(boardID, userID, models.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	if err := s.trashRepo.RestoreBoard(board, s.boardRepo.GetMaxPosition(board.UserID)+1); err != nil {
+		return nil, err
+	}
+
+	board, err = s.boardRepo.FindByIDWithDetails(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	recordActivity(s.activityRepo, boardID, userID, models.EntityBoard, boardID, models.ActionRestored, nil, board)
+	publishEvent(s.broker, events.BoardRestored, boardID, userID, board)
+	return board, nil
+}
+
+// RestoreColumn restores a deleted column at the end of its board
+func (s *TrashService) RestoreColumn(columnID, userID uint) (*models.Column, error) {
+	column, err := s.trashRepo.FindDeletedColumn(columnID)
+	if err != nil {
+		return nil, ErrTrashItemNotFound
+	}
+
+	if err := s.checkRole(column.BoardID, userID, models.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.boardRepo.FindByID(column.BoardID); err != nil {
+		return nil, ErrTrashParentDeleted
+	}
+
+	if err := s.trashRepo.RestoreColumn(column, s.columnRepo.GetMaxPosition(column.BoardID)+1); err != nil {
+		return nil, err
+	}
+
+	column, err = s.columnRepo.FindByIDWithCards(columnID)
+	if err != nil {
+		return nil, err
+	}
+
+	recordActivity(s.activityRepo, column.BoardID, userID, models.EntityColumn, columnID, models.ActionRestored, nil, column)
+	publishEvent(s.broker, events.ColumnRestored, column.BoardID, userID, column)
+	return column, nil
+}
+
+// RestoreCard restores a deleted card at the end of its column, unless the
+// column is full and its board rejects cards over the WIP limit
+func (s *TrashService) RestoreCard(cardID, userID uint) (*models.Card, error) {
+	card, err := s.trashRepo.FindDeletedCard(cardID)
+	if err != nil {
+		return nil, ErrTrashItemNotFound
+	}
+
+	boardID, err := s.cardRepo.GetBoardIDIncludingDeleted(cardID)
+	if err != nil {
+		return nil, ErrTrashItemNotFound
+	}
+
+	if err := s.checkRole(boardID, userID, models.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	column, err := s.columnRepo.FindByID(card.ColumnID)
+	if err != nil {
+		return nil, ErrTrashParentDeleted
+	}
+	if _, err := s.boardRepo.FindByID(boardID); err != nil {
+		return nil, ErrTrashParentDeleted
+	}
+
+	exceeded, err := checkWIPLimit(s.cardRepo, s.boardRepo, column)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.trashRepo.RestoreCard(card, s.cardRepo.GetMaxPosition(card.ColumnID)+1); err != nil {
+		return nil, err
+	}
+
+	card, err = s.cardRepo.FindByIDWithDetails(cardID)
+	if err != nil {
+		return nil, err
+	}
+	card.WIPExceeded = exceeded
+
+	recordActivity(s.activityRepo, boardID, userID, models.EntityCard, cardID, models.ActionRestored, nil, card)
+	publishEvent(s.broker, events.CardRestored, boardID, userID, card)
+	return card, nil
+}
+
+// Purge permanently deletes trash older than the retention period and the
+// contents of its attachments
+func (s *TrashService) Purge() error {
+	if s.retention <= 0 {
+		return nil
+	}
+
+	storageKeys, err := s.trashRepo.PurgeDeletedBefore(time.Now().UTC().Add(-s.retention))
+	if err != nil {
+		return err
+	}
+
+	for _, key := range storageKeys {
+		if err := s.store.Delete(key); err != nil {
+			log.Printf("Failed to delete attachment object %s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+// StartPurger purges expired trash now and then periodically in the background
+func (s *TrashService) StartPurger() {
+	if s.retention <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(trashPurgeInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.Purge(); err != nil {
+				log.Printf("Failed to purge trash: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// checkRole verifies the user's role on a board that may itself be deleted
+func (s *TrashService) checkRole(boardID, userID uint, minRole string) error {
+	role := s.boardRepo.GetUserRoleIncludingDeleted(boardID, userID)
+	if role == "" {
+		return ErrNotBoardOwner
+	}
+	if !models.RoleAtLeast(role, minRole) {
+		return ErrInsufficientRole
+	}
+	return nil
+}
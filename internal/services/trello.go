@@ -0,0 +1,295 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/icl00ud/goban/internal/dto"
+	"github.com/icl00ud/goban/internal/models"
+)
+
+// Column sizes that imported Trello text is truncated to
+const (
+	maxColumnTitleLength    = 100
+	maxCardTitleLength      = 200
+	maxChecklistTitleLength = 200
+	maxChecklistItemLength  = 500
+)
+
+// trelloLabelColors maps Trello label color names to hex colors. Dark and
+// light variants use the base color.
+var trelloLabelColors = map[string]string{
+	"green":  "#61bd4f",
+	"yellow": "#f2d600",
+	"orange": "#ff9f1a",
+	"red":    "#eb5a46",
+	"purple": "#c377e0",
+	"blue":   "#0079bf",
+	"sky":    "#00c2e0",
+	"lime":   "#51e898",
+	"pink":   "#ff78cb",
+	"black":  "#344563",
+}
+
+// trelloNoColor is used for colorless Trello labels
+const trelloNoColor = "#b3bac5"
+
+// TrelloImportOptions controls how a Trello board export is imported
+type TrelloImportOptions struct {
+	// DryRun only reports what would be created and lost
+	DryRun bool
+	// IncludeArchived imports archived lists and cards as regular ones
+	// instead of skipping them
+	IncludeArchived bool
+}
+
+// TrelloImportReport summarizes what a Trello import creates and which
+// Trello data can't be carried over
+type TrelloImportReport struct {
+	Columns        int
+	Cards          int
+	Labels         int
+	Checklists     int
+	ChecklistItems int
+	Comments       int
+	Lost           []string
+}
+
+// ImportTrello maps a Trello board export onto a new board of the user: lists
+// become columns and cards keep their labels, checklists, comments and dates.
+// In a dry run nothing is created and the returned board is nil.
+func (s *ExportService) ImportTrello(userID uint, trello *dto.TrelloBoard, opts TrelloImportOptions) (*models.Board, *TrelloImportReport, error) {
+	if trello.Name == "" && len(trello.Lists) == 0 && len(trello.Cards) == 0 {
+		return nil, nil, fmt.Errorf("%w: not a Trello board export", ErrInvalidImport)
+	}
+
+	data, report := convertTrello(trello, opts.IncludeArchived)
+	if err := validateImport(data); err != nil {
+		return nil, nil, err
+	}
+
+	if opts.DryRun {
+		return nil, report, nil
+	}
+
+	board, err := s.importBoard(userID, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return board, report, nil
+}
+
+// convertTrello converts a Trello board export into goban export data,
+// counting what it contains and noting what is dropped
+func convertTrello(trello *dto.TrelloBoard, includeArchived bool) (*dto.ExportBoardData, *TrelloImportReport) {
+	report := &TrelloImportReport{}
+	lost := func(count int, format string) {
+		if count > 0 {
+			report.Lost = append(report.Lost, fmt.Sprintf(format, count))
+		}
+	}
+
+	name := truncateRunes(strings.TrimSpace(trello.Name), maxBoardNameLength)
+	if name == "" {
+		name = "Trello board"
+	}
+	data := &dto.ExportBoardData{
+		Name:        name,
+		Description: trello.Desc,
+	}
+	if models.ValidateColor(trello.Prefs.BackgroundColor) {
+		data.Color = strings.ToLower(trello.Prefs.BackgroundColor)
+	}
+
+	// Labels get document IDs in Trello order
+	labelIDs := make(map[string]uint, len(trello.Labels))
+	for i, label := range trello.Labels {
+		id := uint(i + 1)
+		labelIDs[label.ID] = id
+		data.Labels = append(data.Labels, dto.ExportLabel{ID: id, Name: trelloLabelName(label), Color: trelloLabelColor(label.Color)})
+	}
+
+	lists := append([]dto.TrelloList(nil), trello.Lists...)
+	sort.SliceStable(lists, func(i, j int) bool { return lists[i].Pos < lists[j].Pos })
+
+	cards := append([]dto.TrelloCard(nil), trello.Cards...)
+	sort.SliceStable(cards, func(i, j int) bool { return cards[i].Pos < cards[j].Pos })
+
+	checklists := append([]dto.TrelloChecklist(nil), trello.Checklists...)
+	sort.SliceStable(checklists, func(i, j int) bool { return checklists[i].Pos < checklists[j].Pos })
+	checklistsByCard := make(map[string][]dto.TrelloChecklist)
+	for _, checklist := range checklists {
+		checklistsByCard[checklist.IDCard] = append(checklistsByCard[checklist.IDCard], checklist)
+	}
+
+	comments := make([]dto.TrelloAction, 0, len(trello.Actions))
+	for _, action := range trello.Actions {
+		if action.Type == "commentCard" && strings.TrimSpace(action.Data.Text) != "" {
+			comments = append(comments, action)
+		}
+	}
+	sort.SliceStable(comments, func(i, j int) bool { return comments[i].Date.Before(comments[j].Date) })
+	commentsByCard := make(map[string][]dto.TrelloAction)
+	for _, comment := range comments {
+		commentsByCard[comment.Data.Card.ID] = append(commentsByCard[comment.Data.Card.ID], comment)
+	}
+
+	var skippedLists, skippedListCards, skippedCards, orphanCards int
+	var members, attachments, customValues, dueComplete, startsDropped int
+	importedCards := make(map[string]bool, len(cards))
+	columnIndex := make(map[string]int, len(lists))
+
+	for _, list := range lists {
+		if list.Closed && !includeArchived {
+			skippedLists++
+			continue
+		}
+		columnIndex[list.ID] = len(data.Columns)
+		title := truncateRunes(strings.TrimSpace(list.Name), maxColumnTitleLength)
+		if title == "" {
+			title = "Untitled list"
+		}
+		data.Columns = append(data.Columns, dto.ExportColumn{Title: title})
+	}
+
+	for _, card := range cards {
+		index, ok := columnIndex[card.IDList]
+		switch {
+		case !ok && listIsArchived(lists, card.IDList):
+			skippedListCards++
+			continue
+		case !ok:
+			orphanCards++
+			continue
+		case card.Closed && !includeArchived:
+			skippedCards++
+			continue
+		}
+
+		title := truncateRunes(strings.TrimSpace(card.Name), maxCardTitleLength)
+		if title == "" {
+			title = "Untitled card"
+		}
+		exported := dto.ExportCard{
+			Title:       title,
+			Description: card.Desc,
+			StartAt:     card.Start,
+			DueAt:       card.Due,
+		}
+		if !validDateRange(exported.StartAt, exported.DueAt) {
+			exported.StartAt = nil
+			startsDropped++
+		}
+		for _, id := range card.IDLabels {
+			if labelID, ok := labelIDs[id]; ok {
+				exported.LabelIDs = append(exported.LabelIDs, labelID)
+			}
+		}
+
+		for _, checklist := range checklistsByCard[card.ID] {
+			exported.Checklists = append(exported.Checklists, trelloChecklist(checklist))
+			report.Checklists++
+			report.ChecklistItems += len(checklist.CheckItems)
+		}
+		for _, comment := range commentsByCard[card.ID] {
+			exported.Comments = append(exported.Comments, dto.ExportComment{Body: comment.Data.Text, CreatedAt: comment.Date})
+			report.Comments++
+		}
+
+		members += len(card.IDMembers)
+		attachments += len(card.Attachments)
+		customValues += len(card.CustomFieldItems)
+		if card.DueComplete {
+			dueComplete++
+		}
+
+		importedCards[card.ID] = true
+		data.Columns[index].Cards = append(data.Columns[index].Cards, exported)
+		report.Cards++
+	}
+
+	var droppedChecklists, droppedComments int
+	for cardID, cardChecklists := range checklistsByCard {
+		if !importedCards[cardID] {
+			droppedChecklists += len(cardChecklists)
+		}
+	}
+	for cardID, cardComments := range commentsByCard {
+		if !importedCards[cardID] {
+			droppedComments += len(cardComments)
+		}
+	}
+
+	report.Columns = len(data.Columns)
+	report.Labels = len(data.Labels)
+
+	lost(skippedLists, "archived lists, skipped unless include_archived is set: %d")
+	lost(skippedListCards, "cards in skipped archived lists: %d")
+	lost(skippedCards, "archived cards, skipped unless include_archived is set: %d")
+	lost(orphanCards, "cards whose list is missing from the export: %d")
+	lost(droppedChecklists, "checklists on skipped cards: %d")
+	lost(droppedComments, "comments on skipped cards: %d")
+	lost(members, "card member assignments, as Trello members are not goban users: %d")
+	lost(attachments, "card attachments, as files are not downloaded: %d")
+	lost(len(trello.CustomFields), "custom fields: %d")
+	lost(customValues, "custom field values on cards: %d")
+	lost(dueComplete, "due date completion marks: %d")
+	lost(startsDropped, "start dates later than the card's due date: %d")
+	lost(report.Comments, "original comment authors, as comments are attributed to you: %d")
+
+	return data, report
+}
+
+// listIsArchived reports whether the list with the ID is an archived list
+func listIsArchived(lists []dto.TrelloList, id string) bool {
+	for _, list := range lists {
+		if list.ID == id {
+			return list.Closed
+		}
+	}
+	return false
+}
+
+// trelloChecklist converts a Trello checklist with its items in order
+func trelloChecklist(checklist dto.TrelloChecklist) dto.ExportChecklist {
+	items := append([]dto.TrelloCheckItem(nil), checklist.CheckItems...)
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Pos < items[j].Pos })
+
+	title := truncateRunes(strings.TrimSpace(checklist.Name), maxChecklistTitleLength)
+	if title == "" {
+		title = "Checklist"
+	}
+	exported := dto.ExportChecklist{Title: title}
+	for _, item := range items {
+		text := truncateRunes(strings.TrimSpace(item.Name), maxChecklistItemLength)
+		if text == "" {
+			text = "Untitled item"
+		}
+		exported.Items = append(exported.Items, dto.ExportChecklistItem{Text: text, Done: item.State == "complete"})
+	}
+	return exported
+}
+
+// trelloLabelName names a Trello label, falling back to its color for
+// labels without a name
+func trelloLabelName(label dto.TrelloLabel) string {
+	name := truncateRunes(strings.TrimSpace(label.Name), maxLabelNameLength)
+	if name != "" {
+		return name
+	}
+	if label.Color == "" {
+		return "Label"
+	}
+	color := strings.ReplaceAll(label.Color, "_", " ")
+	return strings.ToUpper(color[:1]) + color[1:]
+}
+
+// trelloLabelColor maps a Trello label color name to a hex color
+func trelloLabelColor(color string) string {
+	base, _, _ := strings.Cut(color, "_")
+	if hex, ok := trelloLabelColors[base]; ok {
+		return hex
+	}
+	return trelloNoColor
+}
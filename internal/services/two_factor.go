@@ -0,0 +1,297 @@
+package services
+
+import (
+	"crypto/rand"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/icl00ud/goban/internal/models"
+	"github.com/icl00ud/goban/internal/repository"
+	"github.com/icl00ud/goban/internal/utils"
+)
+
+var (
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorNotStarted     = errors.New("start two-factor setup first")
+	ErrInvalidTwoFactorCode    = errors.New("invalid authentication code")
+	ErrInvalidLoginChallenge   = errors.New("login challenge is invalid or expired; sign in again")
+)
+
+// totpIssuer names the app in authenticator apps
+const totpIssuer = "Goban"
+
+// Login challenges must be answered quickly and allow a few typos
+const (
+	loginChallengeTTL    = 5 * time.Minute
+	maxChallengeAttempts = 5
+)
+
+// Recovery codes are shown as two dash-separated groups of lowercase base32
+const (
+	recoveryCodeCount    = 10
+	recoveryCodeLength   = 10
+	recoveryCodeAlphabet = "abcdefghijklmnopqrstuvwxyz234567"
+)
+
+type TwoFactorService struct {
+	userRepo        *repository.UserRepository
+	twoFactorRepo   *repository.TwoFactorRepository
+	maxFailures     int
+	lockoutDuration time.Duration
+}
+
+// NewTwoFactorService creates the two-factor service. Wrong login codes count
+// toward the same lockout as wrong passwords.
+func NewTwoFactorService(userRepo *repository.UserRepository, twoFactorRepo *repository.TwoFactorRepository, maxFailures int, lockoutDuration time.Duration) *TwoFactorService {
+	return &TwoFactorService{
+		userRepo:        userRepo,
+		twoFactorRepo:   twoFactorRepo,
+		maxFailures:     maxFailures,
+		lockoutDuration: lockoutDuration,
+	}
+}
+
+// Status reports whether the user has two-factor authentication enabled and
+// how many recovery codes remain
+func (s *TwoFactorService) Status(userID uint) (bool, int64, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return false, 0, err
+	}
+	if !user.TOTPEnabled {
+		return false, 0, nil
+	}
+	return true, s.twoFactorRepo.CountUnusedRecoveryCodes(userID), nil
+}
+
+// Setup starts enrolment with a new TOTP secret, returning it with the
+// otpauth URI to scan. It takes effect once confirmed with a code.
+func (s *TwoFactorService) Setup(userID uint) (string, string, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return "", "", err
+	}
+	if user.TOTPEnabled {
+		return "", "", ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return "", "", err
+	}
+
+	user.TOTPSecret = secret
+	user.TOTPLastStep = 0
+	if err := s.userRepo.UpdateTOTP(user); err != nil {
+		return "", "", err
+	}
+
+	return secret, utils.TOTPURI(totpIssuer, user.Email, secret), nil
+}
+
+// Confirm enables two-factor authentication once the user proves their
+// authenticator works, returning a fresh set of recovery codes
+func (s *TwoFactorService) Confirm(userID uint, code string) ([]string, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, ErrTwoFactorNotStarted
+	}
+
+	step, ok := utils.ValidateTOTP(user.TOTPSecret, code, time.Now())
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	user.TOTPEnabled = true
+	user.TOTPLastStep = step
+	if err := s.userRepo.UpdateTOTP(user); err != nil {
+		return nil, err
+	}
+
+	return s.replaceRecoveryCodes(userID)
+}
+
+// Disable turns two-factor authentication off after checking a current TOTP
+// or recovery code
+func (s *TwoFactorService) Disable(userID uint, code string) error {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return err
+	}
+	if !user.TOTPEnabled {
+		return ErrTwoFactorNotEnabled
+	}
+	if err := s.verifyCode(user, code); err != nil {
+		return err
+	}
+
+	user.TOTPEnabled = false
+	user.TOTPSecret = ""
+	user.TOTPLastStep = 0
+	if err := s.userRepo.UpdateTOTP(user); err != nil {
+		return err
+	}
+	return s.twoFactorRepo.DeleteRecoveryCodes(userID)
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes after checking a
+// current TOTP or recovery code
+func (s *TwoFactorService) RegenerateRecoveryCodes(userID uint, code string) ([]string, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TOTPEnabled {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	if err := s.verifyCode(user, code); err != nil {
+		return nil, err
+	}
+
+	return s.replaceRecoveryCodes(userID)
+}
+
+// StartChallenge issues the short-lived challenge a user with two-factor
+// authentication exchanges for a session after their password is accepted.
+// Locked accounts get no challenge.
+func (s *TwoFactorService) StartChallenge(user *models.User) (string, time.Time, error) {
+	now := time.Now().UTC()
+	if err := checkAccountLock(user, now); err != nil {
+		return "", time.Time{}, err
+	}
+	if err := s.twoFactorRepo.DeleteExpiredChallenges(now); err != nil {
+		return "", time.Time{}, err
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	challenge := &models.LoginChallenge{
+		TokenHash: hashToken(token),
+		UserID:    user.ID,
+		ExpiresAt: now.Add(loginChallengeTTL),
+	}
+	if err := s.twoFactorRepo.CreateChallenge(challenge); err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, challenge.ExpiresAt, nil
+}
+
+// CompleteChallenge exchanges a login challenge and a TOTP or recovery code
+// for the user. Challenges are single-use and allow a few wrong codes, each
+// of which also counts as a failed login of the account.
+func (s *TwoFactorService) CompleteChallenge(token, code string) (*models.User, error) {
+	challenge, err := s.twoFactorRepo.FindChallengeByHash(hashToken(token))
+	if err != nil || challenge.User.ID == 0 {
+		return nil, ErrInvalidLoginChallenge
+	}
+
+	now := time.Now().UTC()
+	if now.After(challenge.ExpiresAt) || challenge.Attempts >= maxChallengeAttempts {
+		_ = s.twoFactorRepo.DeleteChallenge(challenge.ID)
+		return nil, ErrInvalidLoginChallenge
+	}
+
+	user := &challenge.User
+	if err := checkAccountLock(user, now); err != nil {
+		_ = s.twoFactorRepo.DeleteChallenge(challenge.ID)
+		return nil, err
+	}
+
+	if err := s.verifyCode(user, code); err != nil {
+		if errors.Is(err, ErrInvalidTwoFactorCode) {
+			if err := s.twoFactorRepo.IncrementChallengeAttempts(challenge.ID); err != nil {
+				return nil, err
+			}
+			if s.maxFailures > 0 {
+				if err := s.userRepo.RecordLoginFailure(user.ID, s.maxFailures, now.Add(s.lockoutDuration)); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return nil, err
+	}
+
+	if err := s.twoFactorRepo.DeleteChallenge(challenge.ID); err != nil {
+		return nil, err
+	}
+	if user.FailedLogins > 0 || user.LockedUntil != nil {
+		if err := s.userRepo.ResetLoginFailures(user.ID); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// verifyCode accepts an unused TOTP code or an unused recovery code
+func (s *TwoFactorService) verifyCode(user *models.User, code string) error {
+	if step, ok := utils.ValidateTOTP(user.TOTPSecret, code, time.Now()); ok {
+		claimed, err := s.userRepo.ClaimTOTPStep(user.ID, step)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			return ErrInvalidTwoFactorCode
+		}
+		return nil
+	}
+
+	normalized := normalizeRecoveryCode(code)
+	if len(normalized) != recoveryCodeLength {
+		return ErrInvalidTwoFactorCode
+	}
+	used, err := s.twoFactorRepo.UseRecoveryCode(user.ID, hashToken(normalized), time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+// replaceRecoveryCodes generates and stores a new set of recovery codes,
+// returning them for display
+func (s *TwoFactorService) replaceRecoveryCodes(userID uint) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		buf := make([]byte, recoveryCodeLength)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+		for j, b := range buf {
+			buf[j] = recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)]
+		}
+		raw := string(buf)
+		codes[i] = raw[:recoveryCodeLength/2] + "-" + raw[recoveryCodeLength/2:]
+		hashes[i] = hashToken(raw)
+	}
+
+	if err := s.twoFactorRepo.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// normalizeRecoveryCode drops separators and case from a typed recovery code
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, code)
+}
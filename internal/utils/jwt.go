@@ -6,21 +6,26 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// JWTClaims are the claims of an access token. The registered ID (jti)
+// identifies the server-side session the token belongs to.
 type JWTClaims struct {
 	UserID uint   `json:"user_id"`
 	Email  string `json:"email"`
 	jwt.RegisteredClaims
 }
 
-const TokenExpiration = 7 * 24 * time.Hour // 7 days
+// AccessTokenExpiration is kept short; sessions stay signed in by rotating
+// their refresh token
+const AccessTokenExpiration = 15 * time.Minute
 
-// GenerateToken creates a new JWT token for a user
-func GenerateToken(userID uint, email, secret string) (string, error) {
+// GenerateToken creates a new access token for a user's session
+func GenerateToken(userID uint, email, jti, secret string) (string, error) {
 	claims := JWTClaims{
 		UserID: userID,
 		Email:  email,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(TokenExpiration)),
+			ID:        jti,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenExpiration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Issuer:    "goban",
 		},
@@ -34,7 +39,7 @@ func GenerateToken(userID uint, email, secret string) (string, error) {
 func ValidateToken(tokenString, secret string) (*JWTClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
 		return []byte(secret), nil
-	})
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 	if err != nil {
 		return nil, err
 	}
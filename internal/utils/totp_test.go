@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 key of the RFC 6238 test vectors,
+// "12345678901234567890", in base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// RFC 6238 Appendix B SHA-1 vectors, truncated to six digits
+var rfc6238Vectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+	{20000000000, "353130"},
+}
+
+func TestTOTPCodeMatchesRFC6238(t *testing.T) {
+	key, err := totpEncoding.DecodeString(rfc6238Secret)
+	if err != nil {
+		t.Fatalf("invalid secret: %v", err)
+	}
+
+	for _, tt := range rfc6238Vectors {
+		if got := totpCode(key, tt.unix/30); got != tt.code {
+			t.Errorf("code at %d = %s, want %s", tt.unix, got, tt.code)
+		}
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	for _, tt := range rfc6238Vectors {
+		step, ok := ValidateTOTP(rfc6238Secret, tt.code, time.Unix(tt.unix, 0))
+		if !ok || step != tt.unix/30 {
+			t.Errorf("code %s at %d: got step %d, %v, want step %d", tt.code, tt.unix, step, ok, tt.unix/30)
+		}
+	}
+
+	// 050471 belongs to step 37037037 (unix 1111111110 to 1111111139)
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		unix     int64
+		wantStep int64
+		wantOK   bool
+	}{
+		{"start of step", rfc6238Secret, "050471", 1111111110, 37037037, true},
+		{"end of step", rfc6238Secret, "050471", 1111111139, 37037037, true},
+		{"one step late", rfc6238Secret, "050471", 1111111169, 37037037, true},
+		{"one step early", rfc6238Secret, "050471", 1111111080, 37037037, true},
+		{"two steps late", rfc6238Secret, "050471", 1111111170, 0, false},
+		{"two steps early", rfc6238Secret, "050471", 1111111079, 0, false},
+		{"surrounding whitespace", rfc6238Secret, " 050471\n", 1111111111, 37037037, true},
+		{"lowercase secret", "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", "050471", 1111111111, 37037037, true},
+		{"eight digit code", rfc6238Secret, "14050471", 1111111111, 0, false},
+		{"five digit code", rfc6238Secret, "50471", 1111111111, 0, false},
+		{"empty code", rfc6238Secret, "", 1111111111, 0, false},
+		{"wrong code", rfc6238Secret, "050472", 1111111111, 0, false},
+		{"invalid secret", "not base32!", "050471", 1111111111, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := ValidateTOTP(tt.secret, tt.code, time.Unix(tt.unix, 0))
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("ValidateTOTP = %d, %v, want %d, %v", step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+import type { ReactNode } from 'react'
+import { Link } from 'react-router-dom'
+import { useTranslation } from 'react-i18next'
+
+interface AuthCardProps {
+  title: string
+  subtitle?: string
+  children: ReactNode
+  footer?: ReactNode
+}
+
+// Branded card shared by the password reset and email verification pages
+export function AuthCard({ title, subtitle, children, footer }: AuthCardProps) {
+  const { t } = useTranslation()
+
+  return (
+    <div className="min-h-screen flex items-center justify-center p-4">
+      <div className="w-full max-w-md animate-fade-in">
+        <div className="text-center mb-8">
+          <Link to="/" className="inline-flex flex-col items-center gap-3 group">
+            <img
+              src="/logo.png"
+              alt="GoBan"
+              className="h-16 w-auto drop-shadow-lg transition-transform group-hover:scale-105"
+            />
+            <h1 className="text-3xl font-bold tracking-tight">{t('app.name')}</h1>
+          </Link>
+        </div>
+
+        <div className="bg-card border rounded-xl p-8 shadow-lg shadow-black/5 dark:shadow-black/20">
+          <div className="mb-6">
+            <h2 className="text-xl font-semibold">{title}</h2>
+            {subtitle && <p className="text-sm text-muted-foreground mt-1">{subtitle}</p>}
+          </div>
+
+          {children}
+
+          <div className="mt-6 pt-6 border-t text-center">
+            {footer ?? (
+              <Link
+                to="/login"
+                className="text-sm text-primary font-medium hover:underline underline-offset-4"
+              >
+                {t('auth.backToLogin')}
+              </Link>
+            )}
+          </div>
+        </div>
+      </div>
+    </div>
+  )
+}
@@ -14,9 +14,11 @@ export function LoginForm() {
   const { toggleTheme } = useTheme()
   const [email, setEmail] = useState('')
   const [password, setPassword] = useState('')
+  const [challenge, setChallenge] = useState<string | null>(null)
+  const [code, setCode] = useState('')
   const [error, setError] = useState('')
   const [loading, setLoading] = useState(false)
-  const { login } = useAuth()
+  const { login, verifyTwoFactor } = useAuth()
   const navigate = useNavigate()
 
   const handleSubmit = async (e: React.FormEvent) => {
@@ -25,8 +27,18 @@ export function LoginForm() {
     setLoading(true)
 
     try {
-      await login(email, password)
-      navigate('/')
+      if (challenge) {
+        await verifyTwoFactor(challenge, code)
+        navigate('/')
+        return
+      }
+
+      const pending = await login(email, password)
+      if (pending) {
+        setChallenge(pending)
+      } else {
+        navigate('/')
+      }
     } catch (err) {
       setError(err instanceof Error ? err.message : t('errors.generic'))
     } finally {
@@ -71,9 +83,11 @@ export function LoginForm() {
       {/* Form Card */}
       <div className="bg-card border rounded-xl p-8 shadow-lg shadow-black/5 dark:shadow-black/20">
         <div className="mb-6">
-          <h2 className="text-xl font-semibold">{t('auth.welcomeBack')}</h2>
+          <h2 className="text-xl font-semibold">
+            {challenge ? t('auth.twoFactorTitle') : t('auth.welcomeBack')}
+          </h2>
           <p className="text-sm text-muted-foreground mt-1">
-            {t('auth.loginSubtitle')}
+            {challenge ? t('auth.twoFactorSubtitle') : t('auth.loginSubtitle')}
           </p>
         </div>
 
@@ -85,33 +99,60 @@ export function LoginForm() {
             </div>
           )}
 
-          <div className="space-y-2">
-            <Label htmlFor="email">{t('auth.email')}</Label>
-            <Input
-              id="email"
-              type="email"
-              value={email}
-              onChange={(e) => setEmail(e.target.value)}
-              placeholder={t('auth.emailPlaceholder')}
-              required
-              className="h-11"
-              autoComplete="email"
-            />
-          </div>
+          {challenge ? (
+            <div className="space-y-2">
+              <Label htmlFor="code">{t('auth.twoFactorCode')}</Label>
+              <Input
+                id="code"
+                value={code}
+                onChange={(e) => setCode(e.target.value)}
+                placeholder="123456"
+                required
+                autoFocus
+                className="h-11"
+                autoComplete="one-time-code"
+                inputMode="text"
+              />
+            </div>
+          ) : (
+            <>
+              <div className="space-y-2">
+                <Label htmlFor="email">{t('auth.email')}</Label>
+                <Input
+                  id="email"
+                  type="email"
+                  value={email}
+                  onChange={(e) => setEmail(e.target.value)}
+                  placeholder={t('auth.emailPlaceholder')}
+                  required
+                  className="h-11"
+                  autoComplete="email"
+                />
+              </div>
 
-          <div className="space-y-2">
-            <Label htmlFor="password">{t('auth.password')}</Label>
-            <Input
-              id="password"
-              type="password"
-              value={password}
-              onChange={(e) => setPassword(e.target.value)}
-              placeholder="••••••••"
-              required
-              className="h-11"
-              autoComplete="current-password"
-            />
-          </div>
+              <div className="space-y-2">
+                <div className="flex items-center justify-between">
+                  <Label htmlFor="password">{t('auth.password')}</Label>
+                  <Link
+                    to="/forgot-password"
+                    className="text-xs text-muted-foreground hover:text-primary hover:underline underline-offset-4"
+                  >
+                    {t('auth.forgotPassword')}
+                  </Link>
+                </div>
+                <Input
+                  id="password"
+                  type="password"
+                  value={password}
+                  onChange={(e) => setPassword(e.target.value)}
+                  placeholder="••••••••"
+                  required
+                  className="h-11"
+                  autoComplete="current-password"
+                />
+              </div>
+            </>
+          )}
 
           <Button
             type="submit"
@@ -1,7 +1,9 @@
-import { useState } from 'react'
-import { useNavigate, Link } from 'react-router-dom'
+import { useEffect, useState } from 'react'
+import { useNavigate, useSearchParams, Link } from 'react-router-dom'
 import { useTranslation } from 'react-i18next'
 import { useAuth } from '@/context/AuthContext'
+import { authApi } from '@/lib/api'
+import type { RegistrationPolicy } from '@/types'
 import { useTheme } from '@/context/ThemeContext'
 import { Button } from '@/components/ui/button'
 import { Input } from '@/components/ui/input'
@@ -15,18 +17,32 @@ export function RegisterForm() {
   const [name, setName] = useState('')
   const [email, setEmail] = useState('')
   const [password, setPassword] = useState('')
+  const [searchParams] = useSearchParams()
+  const [inviteCode, setInviteCode] = useState(searchParams.get('invite') ?? '')
+  const [policy, setPolicy] = useState<RegistrationPolicy | null>(null)
   const [error, setError] = useState('')
   const [loading, setLoading] = useState(false)
   const { register } = useAuth()
   const navigate = useNavigate()
 
+  useEffect(() => {
+    authApi.registrationPolicy().then((response) => {
+      if (response.success && response.data) {
+        setPolicy(response.data)
+      }
+    })
+  }, [])
+
+  const closed = policy?.mode === 'closed'
+  const showInviteCode = policy?.mode === 'invite' || inviteCode !== ''
+
   const handleSubmit = async (e: React.FormEvent) => {
     e.preventDefault()
     setError('')
     setLoading(true)
 
     try {
-      await register(email, password, name)
+      await register(email, password, name, inviteCode.trim() || undefined)
       navigate('/')
     } catch (err) {
       setError(err instanceof Error ? err.message : t('errors.generic'))
@@ -81,75 +97,102 @@ export function RegisterForm() {
           </p>
         </div>
 
-        <form onSubmit={handleSubmit} className="space-y-5">
-          {error && (
-            <div className="flex items-center gap-2 p-3 text-sm text-destructive bg-destructive/10 border border-destructive/20 rounded-lg animate-slide-up">
-              <AlertCircle className="h-4 w-4 flex-shrink-0" />
-              <span>{error}</span>
-            </div>
-          )}
-
-          <div className="space-y-2">
-            <Label htmlFor="name">{t('auth.name')}</Label>
-            <Input
-              id="name"
-              type="text"
-              value={name}
-              onChange={(e) => setName(e.target.value)}
-              placeholder={t('auth.namePlaceholder')}
-              required
-              className="h-11"
-              autoComplete="name"
-            />
+        {closed ? (
+          <div className="flex items-center gap-2 p-3 text-sm text-muted-foreground bg-muted border rounded-lg">
+            <AlertCircle className="h-4 w-4 flex-shrink-0" />
+            <span>{t('auth.registrationClosed')}</span>
           </div>
+        ) : (
+          <form onSubmit={handleSubmit} className="space-y-5">
+            {error && (
+              <div className="flex items-center gap-2 p-3 text-sm text-destructive bg-destructive/10 border border-destructive/20 rounded-lg animate-slide-up">
+                <AlertCircle className="h-4 w-4 flex-shrink-0" />
+                <span>{error}</span>
+              </div>
+            )}
 
-          <div className="space-y-2">
-            <Label htmlFor="email">{t('auth.email')}</Label>
-            <Input
-              id="email"
-              type="email"
-              value={email}
-              onChange={(e) => setEmail(e.target.value)}
-              placeholder={t('auth.emailPlaceholder')}
-              required
-              className="h-11"
-              autoComplete="email"
-            />
-          </div>
+            <div className="space-y-2">
+              <Label htmlFor="name">{t('auth.name')}</Label>
+              <Input
+                id="name"
+                type="text"
+                value={name}
+                onChange={(e) => setName(e.target.value)}
+                placeholder={t('auth.namePlaceholder')}
+                required
+                className="h-11"
+                autoComplete="name"
+              />
+            </div>
 
-          <div className="space-y-2">
-            <Label htmlFor="password">{t('auth.password')}</Label>
-            <Input
-              id="password"
-              type="password"
-              value={password}
-              onChange={(e) => setPassword(e.target.value)}
-              placeholder="••••••••"
-              minLength={6}
-              required
-              className="h-11"
-              autoComplete="new-password"
-            />
-          </div>
+            <div className="space-y-2">
+              <Label htmlFor="email">{t('auth.email')}</Label>
+              <Input
+                id="email"
+                type="email"
+                value={email}
+                onChange={(e) => setEmail(e.target.value)}
+                placeholder={t('auth.emailPlaceholder')}
+                required
+                className="h-11"
+                autoComplete="email"
+              />
+              {policy && policy.mode === 'open' && policy.allowed_domains.length > 0 && !showInviteCode && (
+                <p className="text-xs text-muted-foreground">
+                  {t('auth.allowedDomains', { domains: policy.allowed_domains.join(', ') })}
+                </p>
+              )}
+            </div>
+
+            <div className="space-y-2">
+              <Label htmlFor="password">{t('auth.password')}</Label>
+              <Input
+                id="password"
+                type="password"
+                value={password}
+                onChange={(e) => setPassword(e.target.value)}
+                placeholder="••••••••"
+                minLength={6}
+                required
+                className="h-11"
+                autoComplete="new-password"
+              />
+            </div>
 
-          <Button
-            type="submit"
-            className="w-full h-11 text-base font-medium"
-            disabled={loading}
-          >
-            {loading ? (
-              <>
-                <Loader2 className="mr-2 h-4 w-4 animate-spin" />
-                {t('auth.creatingAccount')}
-              </>
-            ) : (
-              <>
-                {t('auth.createAccount')}
-                <ArrowRight className="ml-2 h-4 w-4" />
-              </>
+            {showInviteCode && (
+              <div className="space-y-2">
+                <Label htmlFor="inviteCode">{t('auth.inviteCode')}</Label>
+                <Input
+                  id="inviteCode"
+                  type="text"
+                  value={inviteCode}
+                  onChange={(e) => setInviteCode(e.target.value)}
+                  required={policy?.mode === 'invite'}
+                  className="h-11 font-mono"
+                  autoComplete="off"
+                />
+              </div>
             )}
-          </Button>
-        </form>
+
+            <Button
+              type="submit"
+              className="w-full h-11 text-base font-medium"
+              disabled={loading}
+            >
+              {loading ? (
+                <>
+                  <Loader2 className="mr-2 h-4 w-4 animate-spin" />
+                  {t('auth.creatingAccount')}
+                </>
+              ) : (
+                <>
+                  {t('auth.createAccount')}
+                  <ArrowRight className="ml-2 h-4 w-4" />
+                </>
+              )}
+            </Button>
+          </form>
+        )}
 
         <div className="mt-6 pt-6 border-t text-center">
           <p className="text-sm text-muted-foreground">
@@ -9,7 +9,7 @@ import {
   DropdownMenuSeparator,
   DropdownMenuTrigger,
 } from '@/components/ui/dropdown-menu'
-import { Moon, Sun, User, LogOut, ChevronDown } from 'lucide-react'
+import { Moon, Sun, User, LogOut, ChevronDown, Shield } from 'lucide-react'
 import { Link, useNavigate } from 'react-router-dom'
 import { LanguageSwitcher } from '@/components/LanguageSwitcher'
 
@@ -90,6 +90,15 @@ export function Header() {
                   </p>
                 </div>
                 <DropdownMenuSeparator />
+                {user.is_admin && (
+                  <>
+                    <DropdownMenuItem onClick={() => navigate('/admin')} className="cursor-pointer">
+                      <Shield className="mr-2 h-4 w-4" />
+                      {t('nav.admin')}
+                    </DropdownMenuItem>
+                    <DropdownMenuSeparator />
+                  </>
+                )}
                 <DropdownMenuItem
                   onClick={handleLogout}
                   className="text-destructive focus:text-destructive cursor-pointer"
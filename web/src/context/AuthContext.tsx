@@ -5,8 +5,10 @@ import { authApi } from '@/lib/api'
 interface AuthContextValue {
   user: User | null
   loading: boolean
-  login: (email: string, password: string) => Promise<void>
-  register: (email: string, password: string, name: string) => Promise<void>
+  // Resolves with a challenge when the account requires a second factor
+  login: (email: string, password: string) => Promise<string | null>
+  verifyTwoFactor: (challenge: string, code: string) => Promise<void>
+  register: (email: string, password: string, name: string, inviteCode?: string) => Promise<void>
   logout: () => Promise<void>
 }
 
@@ -35,6 +37,18 @@ export function AuthProvider({ children }: { children: React.ReactNode }) {
 
   const login = async (email: string, password: string) => {
     const response = await authApi.login({ email, password })
+    if (!response.success || !response.data) {
+      throw new Error(response.error || 'Login failed')
+    }
+    if ('two_factor_required' in response.data) {
+      return response.data.challenge
+    }
+    setUser(response.data)
+    return null
+  }
+
+  const verifyTwoFactor = async (challenge: string, code: string) => {
+    const response = await authApi.loginTwoFactor({ challenge, code })
     if (response.success && response.data) {
       setUser(response.data)
     } else {
@@ -42,8 +56,8 @@ export function AuthProvider({ children }: { children: React.ReactNode }) {
     }
   }
 
-  const register = async (email: string, password: string, name: string) => {
-    const response = await authApi.register({ email, password, name })
+  const register = async (email: string, password: string, name: string, inviteCode?: string) => {
+    const response = await authApi.register({ email, password, name, invite_code: inviteCode })
     if (response.success && response.data) {
       // Auto-login after registration
       await login(email, password)
@@ -58,7 +72,7 @@ export function AuthProvider({ children }: { children: React.ReactNode }) {
   }
 
   return (
-    <AuthContext.Provider value={{ user, loading, login, register, logout }}>
+    <AuthContext.Provider value={{ user, loading, login, verifyTwoFactor, register, logout }}>
       {children}
     </AuthContext.Provider>
   )
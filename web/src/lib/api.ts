@@ -5,6 +5,8 @@ import type {
   Column,
   Card,
   LoginRequest,
+  LoginChallenge,
+  TwoFactorLoginRequest,
   RegisterRequest,
   CreateBoardRequest,
   UpdateBoardRequest,
@@ -16,22 +18,57 @@ import type {
   UpdateCardRequest,
   MoveCardRequest,
   ReorderCardsRequest,
+  RegistrationPolicy,
+  AdminUser,
+  UpdateAdminUserRequest,
+  Invite,
+  CreatedInvite,
+  CreateInviteRequest,
 } from '@/types'
 
 const API_BASE = '/api/v1'
 
+// Endpoints whose 401 means bad credentials rather than an expired access token
+const NO_REFRESH_ENDPOINTS = ['/auth/login', '/auth/login/2fa', '/auth/register', '/auth/refresh', '/auth/logout']
+
+// Shared so concurrent requests that hit an expired access token refresh once
+let refreshing: Promise<boolean> | null = null
+
+function refreshSession(): Promise<boolean> {
+  if (!refreshing) {
+    refreshing = fetch(`${API_BASE}/auth/refresh`, {
+      method: 'POST',
+      credentials: 'include',
+    })
+      .then((response) => response.ok)
+      .catch(() => false)
+      .finally(() => {
+        refreshing = null
+      })
+  }
+  return refreshing
+}
+
 async function request<T>(
   endpoint: string,
   options: RequestInit = {}
 ): Promise<ApiResponse<T>> {
-  const response = await fetch(`${API_BASE}${endpoint}`, {
-    ...options,
-    headers: {
-      'Content-Type': 'application/json',
-      ...options.headers,
-    },
-    credentials: 'include',
-  })
+  const send = () =>
+    fetch(`${API_BASE}${endpoint}`, {
+      ...options,
+      headers: {
+        'Content-Type': 'application/json',
+        ...options.headers,
+      },
+      credentials: 'include',
+    })
+
+  let response = await send()
+
+  // Access tokens are short-lived; refresh the session and retry once
+  if (response.status === 401 && !NO_REFRESH_ENDPOINTS.includes(endpoint) && (await refreshSession())) {
+    response = await send()
+  }
 
   const data = await response.json()
   return data
@@ -39,6 +76,8 @@ async function request<T>(
 
 // Auth API
 export const authApi = {
+  registrationPolicy: () => request<RegistrationPolicy>('/auth/registration'),
+
   register: (data: RegisterRequest) =>
     request<User>('/auth/register', {
       method: 'POST',
@@ -46,7 +85,13 @@ export const authApi = {
     }),
 
   login: (data: LoginRequest) =>
-    request<User>('/auth/login', {
+    request<User | LoginChallenge>('/auth/login', {
+      method: 'POST',
+      body: JSON.stringify(data),
+    }),
+
+  loginTwoFactor: (data: TwoFactorLoginRequest) =>
+    request<User>('/auth/login/2fa', {
       method: 'POST',
       body: JSON.stringify(data),
     }),
@@ -57,6 +102,65 @@ export const authApi = {
     }),
 
   me: () => request<User>('/auth/me'),
+
+  forgotPassword: (email: string) =>
+    request<void>('/auth/forgot-password', {
+      method: 'POST',
+      body: JSON.stringify({ email }),
+    }),
+
+  resetPassword: (token: string, password: string) =>
+    request<void>('/auth/reset-password', {
+      method: 'POST',
+      body: JSON.stringify({ token, password }),
+    }),
+
+  verifyEmail: (token: string) =>
+    request<User>('/auth/verify-email', {
+      method: 'POST',
+      body: JSON.stringify({ token }),
+    }),
+
+  resendVerification: () =>
+    request<void>('/auth/verify-email/resend', {
+      method: 'POST',
+    }),
+}
+
+// Administration API
+export const adminApi = {
+  listUsers: (query = '') =>
+    request<AdminUser[]>(`/admin/users${query ? `?q=${encodeURIComponent(query)}` : ''}`),
+
+  updateUser: (id: number, data: UpdateAdminUserRequest) =>
+    request<AdminUser>(`/admin/users/${id}`, {
+      method: 'PUT',
+      body: JSON.stringify(data),
+    }),
+
+  deleteUser: (id: number) =>
+    request<void>(`/admin/users/${id}`, {
+      method: 'DELETE',
+    }),
+
+  resetPassword: (id: number, password?: string) =>
+    request<void>(`/admin/users/${id}/reset-password`, {
+      method: 'POST',
+      body: JSON.stringify({ password: password ?? '' }),
+    }),
+
+  listInvites: () => request<Invite[]>('/admin/invites'),
+
+  createInvite: (data: CreateInviteRequest) =>
+    request<CreatedInvite>('/admin/invites', {
+      method: 'POST',
+      body: JSON.stringify(data),
+    }),
+
+  revokeInvite: (id: number) =>
+    request<void>(`/admin/invites/${id}`, {
+      method: 'DELETE',
+    }),
 }
 
 // Board API
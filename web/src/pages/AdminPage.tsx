@@ -0,0 +1,262 @@
+import { useState, useEffect, useCallback } from 'react'
+import { Navigate } from 'react-router-dom'
+import { useTranslation } from 'react-i18next'
+import { useAuth } from '@/context/AuthContext'
+import { adminApi } from '@/lib/api'
+import type { AdminUser, Invite, CreatedInvite } from '@/types'
+import { Button } from '@/components/ui/button'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { AlertCircle, Copy, KeyRound, Loader2, Plus, Search, Shield, Trash2, UserCheck, UserX } from 'lucide-react'
+import { cn } from '@/lib/utils'
+
+export function AdminPage() {
+  const { t } = useTranslation()
+  const { user } = useAuth()
+  const [users, setUsers] = useState<AdminUser[]>([])
+  const [invites, setInvites] = useState<Invite[]>([])
+  const [query, setQuery] = useState('')
+  const [inviteEmail, setInviteEmail] = useState('')
+  const [createdInvite, setCreatedInvite] = useState<CreatedInvite | null>(null)
+  const [loading, setLoading] = useState(true)
+  const [error, setError] = useState('')
+  const [notice, setNotice] = useState('')
+
+  const loadUsers = useCallback(async (search: string) => {
+    const response = await adminApi.listUsers(search)
+    if (response.success && response.data) {
+      setUsers(response.data)
+    } else {
+      setError(response.error || t('errors.generic'))
+    }
+  }, [t])
+
+  const loadInvites = useCallback(async () => {
+    const response = await adminApi.listInvites()
+    if (response.success && response.data) {
+      setInvites(response.data)
+    }
+  }, [])
+
+  useEffect(() => {
+    if (!user?.is_admin) return
+    Promise.all([loadUsers(''), loadInvites()]).finally(() => setLoading(false))
+  }, [user, loadUsers, loadInvites])
+
+  if (!user?.is_admin) {
+    return <Navigate to="/" replace />
+  }
+
+  // run shows the outcome of an action and reloads the affected list
+  const run = async (action: () => Promise<{ success: boolean; error?: string; message?: string }>, reload: () => Promise<void>) => {
+    setError('')
+    setNotice('')
+    const response = await action()
+    if (!response.success) {
+      setError(response.error || t('errors.generic'))
+      return
+    }
+    if (response.message) {
+      setNotice(response.message)
+    }
+    await reload()
+  }
+
+  const reloadUsers = () => loadUsers(query)
+
+  const handleSearch = (e: React.FormEvent) => {
+    e.preventDefault()
+    loadUsers(query)
+  }
+
+  const handleDelete = (target: AdminUser) => {
+    if (!confirm(t('admin.deleteUserConfirm', { name: target.name }))) return
+    run(() => adminApi.deleteUser(target.id), reloadUsers)
+  }
+
+  const handleCreateInvite = async (e: React.FormEvent) => {
+    e.preventDefault()
+    setError('')
+    const response = await adminApi.createInvite({ email: inviteEmail.trim() || undefined })
+    if (!response.success || !response.data) {
+      setError(response.error || t('errors.generic'))
+      return
+    }
+    setCreatedInvite(response.data)
+    setInviteEmail('')
+    await loadInvites()
+  }
+
+  if (loading) {
+    return (
+      <div className="flex items-center justify-center py-24">
+        <Loader2 className="h-8 w-8 animate-spin text-primary" />
+      </div>
+    )
+  }
+
+  return (
+    <div className="container mx-auto p-6 max-w-6xl space-y-10">
+      <div>
+        <h1 className="text-2xl font-bold tracking-tight flex items-center gap-2">
+          <Shield className="h-6 w-6 text-primary" />
+          {t('admin.title')}
+        </h1>
+        <p className="text-muted-foreground mt-1">{t('admin.subtitle')}</p>
+      </div>
+
+      {error && (
+        <div className="flex items-center gap-2 p-3 text-sm text-destructive bg-destructive/10 border border-destructive/20 rounded-lg">
+          <AlertCircle className="h-4 w-4 flex-shrink-0" />
+          <span>{error}</span>
+        </div>
+      )}
+      {notice && (
+        <div className="p-3 text-sm bg-primary/10 border border-primary/20 rounded-lg">{notice}</div>
+      )}
+
+      {/* Users */}
+      <section className="space-y-4">
+        <div className="flex items-center justify-between gap-4">
+          <h2 className="text-xl font-semibold">{t('admin.users')}</h2>
+          <form onSubmit={handleSearch} className="relative w-full max-w-xs">
+            <Search className="absolute left-3 top-1/2 -translate-y-1/2 h-4 w-4 text-muted-foreground" />
+            <Input
+              value={query}
+              onChange={(e) => setQuery(e.target.value)}
+              placeholder={t('admin.searchUsers')}
+              className="pl-9"
+            />
+          </form>
+        </div>
+
+        <div className="bg-card border rounded-xl divide-y">
+          {users.map((member) => {
+            const self = member.id === user.id
+            return (
+              <div key={member.id} className="flex items-center justify-between gap-4 p-4">
+                <div className="min-w-0">
+                  <p className={cn('font-medium truncate', member.disabled_at && 'text-muted-foreground line-through')}>
+                    {member.name}
+                    {member.is_admin && (
+                      <span className="ml-2 text-xs font-normal text-primary">{t('admin.adminBadge')}</span>
+                    )}
+                    {member.disabled_at && (
+                      <span className="ml-2 text-xs font-normal text-destructive">{t('admin.disabledBadge')}</span>
+                    )}
+                  </p>
+                  <p className="text-sm text-muted-foreground truncate">{member.email}</p>
+                </div>
+                {!self && (
+                  <div className="flex items-center gap-1 flex-shrink-0">
+                    <Button
+                      variant="ghost"
+                      size="sm"
+                      onClick={() => run(() => adminApi.updateUser(member.id, { is_admin: !member.is_admin }), reloadUsers)}
+                    >
+                      <Shield className="mr-1 h-4 w-4" />
+                      {member.is_admin ? t('admin.revokeAdmin') : t('admin.makeAdmin')}
+                    </Button>
+                    <Button
+                      variant="ghost"
+                      size="sm"
+                      onClick={() => run(() => adminApi.updateUser(member.id, { disabled: !member.disabled_at }), reloadUsers)}
+                    >
+                      {member.disabled_at ? <UserCheck className="mr-1 h-4 w-4" /> : <UserX className="mr-1 h-4 w-4" />}
+                      {member.disabled_at ? t('admin.enable') : t('admin.disable')}
+                    </Button>
+                    <Button
+                      variant="ghost"
+                      size="sm"
+                      onClick={() => run(() => adminApi.resetPassword(member.id), reloadUsers)}
+                    >
+                      <KeyRound className="mr-1 h-4 w-4" />
+                      {t('admin.sendResetLink')}
+                    </Button>
+                    <Button
+                      variant="ghost"
+                      size="icon"
+                      onClick={() => handleDelete(member)}
+                      aria-label={t('common.delete')}
+                      className="text-destructive hover:text-destructive"
+                    >
+                      <Trash2 className="h-4 w-4" />
+                    </Button>
+                  </div>
+                )}
+              </div>
+            )
+          })}
+        </div>
+      </section>
+
+      {/* Invites */}
+      <section className="space-y-4">
+        <h2 className="text-xl font-semibold">{t('admin.invites')}</h2>
+
+        <form onSubmit={handleCreateInvite} className="flex items-end gap-2 max-w-lg">
+          <div className="flex-1 space-y-2">
+            <Label htmlFor="inviteEmail">{t('admin.inviteEmail')}</Label>
+            <Input
+              id="inviteEmail"
+              type="email"
+              value={inviteEmail}
+              onChange={(e) => setInviteEmail(e.target.value)}
+              placeholder={t('auth.emailPlaceholder')}
+            />
+          </div>
+          <Button type="submit" className="gap-2">
+            <Plus className="h-4 w-4" />
+            {t('admin.createInvite')}
+          </Button>
+        </form>
+
+        {createdInvite && (
+          <div className="p-4 space-y-2 bg-primary/10 border border-primary/20 rounded-lg">
+            <p className="text-sm">{t('admin.inviteCreated')}</p>
+            <div className="flex items-center gap-2">
+              <code className="flex-1 text-xs break-all bg-background border rounded px-2 py-1">{createdInvite.link}</code>
+              <Button
+                variant="ghost"
+                size="icon"
+                onClick={() => navigator.clipboard.writeText(createdInvite.link)}
+                aria-label={t('admin.copyLink')}
+              >
+                <Copy className="h-4 w-4" />
+              </Button>
+            </div>
+          </div>
+        )}
+
+        {invites.length === 0 ? (
+          <p className="text-sm text-muted-foreground">{t('admin.noInvites')}</p>
+        ) : (
+          <div className="bg-card border rounded-xl divide-y">
+            {invites.map((invite) => (
+              <div key={invite.id} className="flex items-center justify-between gap-4 p-4">
+                <div className="min-w-0 text-sm">
+                  <p className="font-medium truncate">{invite.email || t('admin.anyEmail')}</p>
+                  <p className="text-muted-foreground">
+                    {invite.used_at
+                      ? t('admin.inviteUsed', { name: invite.used_by ?? '' })
+                      : t('admin.inviteCreatedBy', { name: invite.created_by })}
+                  </p>
+                </div>
+                {!invite.used_at && (
+                  <Button
+                    variant="ghost"
+                    size="sm"
+                    onClick={() => run(() => adminApi.revokeInvite(invite.id), loadInvites)}
+                    className="text-destructive hover:text-destructive"
+                  >
+                    {t('admin.revokeInvite')}
+                  </Button>
+                )}
+              </div>
+            ))}
+          </div>
+        )}
+      </section>
+    </div>
+  )
+}
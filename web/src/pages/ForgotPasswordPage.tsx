@@ -0,0 +1,74 @@
+import { useState } from 'react'
+import { useTranslation } from 'react-i18next'
+import { authApi } from '@/lib/api'
+import { AuthCard } from '@/components/auth/AuthCard'
+import { Button } from '@/components/ui/button'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { AlertCircle, CheckCircle2, Loader2 } from 'lucide-react'
+
+export function ForgotPasswordPage() {
+  const { t } = useTranslation()
+  const [email, setEmail] = useState('')
+  const [sent, setSent] = useState(false)
+  const [error, setError] = useState('')
+  const [loading, setLoading] = useState(false)
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault()
+    setError('')
+    setLoading(true)
+
+    try {
+      const response = await authApi.forgotPassword(email)
+      if (!response.success) {
+        throw new Error(response.error || t('errors.generic'))
+      }
+      setSent(true)
+    } catch (err) {
+      setError(err instanceof Error ? err.message : t('errors.generic'))
+    } finally {
+      setLoading(false)
+    }
+  }
+
+  return (
+    <AuthCard title={t('auth.forgotPasswordTitle')} subtitle={t('auth.forgotPasswordSubtitle')}>
+      {sent ? (
+        <div className="flex items-start gap-2 p-3 text-sm bg-primary/10 border border-primary/20 rounded-lg">
+          <CheckCircle2 className="h-4 w-4 mt-0.5 flex-shrink-0 text-primary" />
+          <span>{t('auth.resetLinkSent')}</span>
+        </div>
+      ) : (
+        <form onSubmit={handleSubmit} className="space-y-5">
+          {error && (
+            <div className="flex items-center gap-2 p-3 text-sm text-destructive bg-destructive/10 border border-destructive/20 rounded-lg animate-slide-up">
+              <AlertCircle className="h-4 w-4 flex-shrink-0" />
+              <span>{error}</span>
+            </div>
+          )}
+
+          <div className="space-y-2">
+            <Label htmlFor="email">{t('auth.email')}</Label>
+            <Input
+              id="email"
+              type="email"
+              value={email}
+              onChange={(e) => setEmail(e.target.value)}
+              placeholder={t('auth.emailPlaceholder')}
+              required
+              autoFocus
+              className="h-11"
+              autoComplete="email"
+            />
+          </div>
+
+          <Button type="submit" className="w-full h-11 text-base font-medium" disabled={loading}>
+            {loading && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+            {t('auth.sendResetLink')}
+          </Button>
+        </form>
+      )}
+    </AuthCard>
+  )
+}
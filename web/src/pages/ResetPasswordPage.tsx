@@ -0,0 +1,104 @@
+import { useState } from 'react'
+import { Link, useSearchParams } from 'react-router-dom'
+import { useTranslation } from 'react-i18next'
+import { authApi } from '@/lib/api'
+import { AuthCard } from '@/components/auth/AuthCard'
+import { Button } from '@/components/ui/button'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { AlertCircle, CheckCircle2, Loader2 } from 'lucide-react'
+
+export function ResetPasswordPage() {
+  const { t } = useTranslation()
+  const [searchParams] = useSearchParams()
+  const token = searchParams.get('token') ?? ''
+  const [password, setPassword] = useState('')
+  const [confirmPassword, setConfirmPassword] = useState('')
+  const [done, setDone] = useState(false)
+  const [error, setError] = useState('')
+  const [loading, setLoading] = useState(false)
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault()
+    setError('')
+
+    if (password !== confirmPassword) {
+      setError(t('auth.passwordsDoNotMatch'))
+      return
+    }
+
+    setLoading(true)
+    try {
+      const response = await authApi.resetPassword(token, password)
+      if (!response.success) {
+        throw new Error(response.error || t('errors.generic'))
+      }
+      setDone(true)
+    } catch (err) {
+      setError(err instanceof Error ? err.message : t('errors.generic'))
+    } finally {
+      setLoading(false)
+    }
+  }
+
+  return (
+    <AuthCard title={t('auth.resetPasswordTitle')} subtitle={done ? undefined : t('auth.resetPasswordSubtitle')}>
+      {done ? (
+        <div className="space-y-5">
+          <div className="flex items-start gap-2 p-3 text-sm bg-primary/10 border border-primary/20 rounded-lg">
+            <CheckCircle2 className="h-4 w-4 mt-0.5 flex-shrink-0 text-primary" />
+            <span>{t('auth.passwordResetDone')}</span>
+          </div>
+          <Button asChild className="w-full h-11 text-base font-medium">
+            <Link to="/login">{t('auth.signIn')}</Link>
+          </Button>
+        </div>
+      ) : (
+        <form onSubmit={handleSubmit} className="space-y-5">
+          {(error || !token) && (
+            <div className="flex items-center gap-2 p-3 text-sm text-destructive bg-destructive/10 border border-destructive/20 rounded-lg animate-slide-up">
+              <AlertCircle className="h-4 w-4 flex-shrink-0" />
+              <span>{error || t('auth.invalidLink')}</span>
+            </div>
+          )}
+
+          <div className="space-y-2">
+            <Label htmlFor="password">{t('auth.newPassword')}</Label>
+            <Input
+              id="password"
+              type="password"
+              value={password}
+              onChange={(e) => setPassword(e.target.value)}
+              placeholder="••••••••"
+              required
+              minLength={6}
+              autoFocus
+              className="h-11"
+              autoComplete="new-password"
+            />
+          </div>
+
+          <div className="space-y-2">
+            <Label htmlFor="confirmPassword">{t('auth.confirmPassword')}</Label>
+            <Input
+              id="confirmPassword"
+              type="password"
+              value={confirmPassword}
+              onChange={(e) => setConfirmPassword(e.target.value)}
+              placeholder="••••••••"
+              required
+              minLength={6}
+              className="h-11"
+              autoComplete="new-password"
+            />
+          </div>
+
+          <Button type="submit" className="w-full h-11 text-base font-medium" disabled={loading || !token}>
+            {loading && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+            {t('auth.resetPassword')}
+          </Button>
+        </form>
+      )}
+    </AuthCard>
+  )
+}
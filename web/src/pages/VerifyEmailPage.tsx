@@ -0,0 +1,67 @@
+import { useEffect, useRef, useState } from 'react'
+import { Link, useSearchParams } from 'react-router-dom'
+import { useTranslation } from 'react-i18next'
+import { authApi } from '@/lib/api'
+import { AuthCard } from '@/components/auth/AuthCard'
+import { Button } from '@/components/ui/button'
+import { AlertCircle, CheckCircle2, Loader2 } from 'lucide-react'
+
+type Status = 'verifying' | 'verified' | 'failed'
+
+export function VerifyEmailPage() {
+  const { t } = useTranslation()
+  const [searchParams] = useSearchParams()
+  const token = searchParams.get('token') ?? ''
+  const [status, setStatus] = useState<Status>(token ? 'verifying' : 'failed')
+  const [error, setError] = useState('')
+  // Tokens are single-use, so only send it once even if the effect re-runs
+  const sent = useRef(false)
+
+  useEffect(() => {
+    if (!token || sent.current) return
+    sent.current = true
+
+    authApi
+      .verifyEmail(token)
+      .then((response) => {
+        if (!response.success) {
+          throw new Error(response.error || t('errors.generic'))
+        }
+        setStatus('verified')
+      })
+      .catch((err) => {
+        setError(err instanceof Error ? err.message : t('errors.generic'))
+        setStatus('failed')
+      })
+  }, [token, t])
+
+  return (
+    <AuthCard title={t('auth.verifyEmailTitle')}>
+      {status === 'verifying' && (
+        <div className="flex items-center justify-center gap-2 text-sm text-muted-foreground">
+          <Loader2 className="h-4 w-4 animate-spin" />
+          {t('auth.verifyingEmail')}
+        </div>
+      )}
+
+      {status === 'verified' && (
+        <div className="space-y-5">
+          <div className="flex items-start gap-2 p-3 text-sm bg-primary/10 border border-primary/20 rounded-lg">
+            <CheckCircle2 className="h-4 w-4 mt-0.5 flex-shrink-0 text-primary" />
+            <span>{t('auth.emailVerified')}</span>
+          </div>
+          <Button asChild className="w-full h-11 text-base font-medium">
+            <Link to="/">{t('auth.continue')}</Link>
+          </Button>
+        </div>
+      )}
+
+      {status === 'failed' && (
+        <div className="flex items-center gap-2 p-3 text-sm text-destructive bg-destructive/10 border border-destructive/20 rounded-lg">
+          <AlertCircle className="h-4 w-4 flex-shrink-0" />
+          <span>{error || t('auth.invalidLink')}</span>
+        </div>
+      )}
+    </AuthCard>
+  )
+}
@@ -3,6 +3,50 @@ export interface User {
   id: number
   email: string
   name: string
+  email_verified: boolean
+  is_admin: boolean
+}
+
+// Administration types
+export interface AdminUser {
+  id: number
+  email: string
+  name: string
+  is_admin: boolean
+  disabled_at: string | null
+  email_verified: boolean
+  two_factor_enabled: boolean
+  created_at: string
+}
+
+export interface UpdateAdminUserRequest {
+  is_admin?: boolean
+  disabled?: boolean
+}
+
+export interface Invite {
+  id: number
+  email: string
+  expires_at: string | null
+  used_at: string | null
+  used_by: string | null
+  created_by: string
+  created_at: string
+}
+
+export interface CreatedInvite extends Invite {
+  code: string
+  link: string
+}
+
+export interface CreateInviteRequest {
+  email?: string
+  expires_at?: string
+}
+
+export interface RegistrationPolicy {
+  mode: 'open' | 'invite' | 'closed'
+  allowed_domains: string[]
 }
 
 // Board types
@@ -49,10 +93,23 @@ export interface LoginRequest {
   password: string
 }
 
+// Returned by login instead of the user when a second factor is required
+export interface LoginChallenge {
+  two_factor_required: true
+  challenge: string
+  expires_at: string
+}
+
+export interface TwoFactorLoginRequest {
+  challenge: string
+  code: string
+}
+
 export interface RegisterRequest {
   email: string
   password: string
   name: string
+  invite_code?: string
 }
 
 // Board request types